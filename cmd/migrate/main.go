@@ -0,0 +1,117 @@
+// Command migrate copies all module versions from one storage backend
+// of the proxy to another, e.g.
+//
+//	migrate -config_file config.toml -from mongo -to minio
+//
+// Each version is verified against its go.sum checksums after it is
+// copied. The progress is saved in the state file, so an interrupted
+// migration continues where it stopped when the command is run again.
+// Set Proxy.FallbackStorageType to the old backend to keep serving
+// the versions that are not copied yet while the migration runs.
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gomods/athens/cmd/proxy/actions"
+	"github.com/gomods/athens/pkg/config"
+	athenslog "github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage/migrate"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	configFile   = flag.String("config_file", filepath.Join("..", "..", "config.dev.toml"), "The path to the config file")
+	toConfigFile = flag.String("to_config_file", "", "The path to the config file of the destination, if it differs from config_file")
+	from         = flag.String("from", "", "The storage type to copy from")
+	to           = flag.String("to", "", "The storage type to copy to")
+	stateFile    = flag.String("state_file", "migrate.state", "The file that keeps the progress of the migration")
+	pageSize     = flag.Int("page_size", 100, "The number of versions to copy between checkpoints")
+	dryRun       = flag.Bool("dry_run", false, "Only count the versions to copy")
+)
+
+func main() {
+	flag.Parse()
+	if *from == "" || *to == "" {
+		log.Fatal("Both -from and -to storage types must be provided")
+	}
+	if *toConfigFile == "" {
+		*toConfigFile = *configFile
+	}
+	fromConf, err := config.ParseConfigFile(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	toConf, err := config.ParseConfigFile(*toConfigFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// copy from and to the backends themselves, not through a disk cache
+	fromConf.Storage.DiskCache = nil
+	toConf.Storage.DiskCache = nil
+	lggr := athenslog.New(fromConf.CloudRuntime, logrus.InfoLevel)
+	src, err := actions.GetStorage(*from, fromConf.Storage, lggr)
+	if err != nil {
+		log.Fatalf("error getting storage to copy from (%s)", err)
+	}
+	dst, err := actions.GetStorage(*to, toConf.Storage, lggr)
+	if err != nil {
+		log.Fatalf("error getting storage to copy to (%s)", err)
+	}
+
+	token, err := readState(*stateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if token != "" {
+		log.Printf("resuming after %s", token)
+	}
+	res, err := migrate.Migrate(context.Background(), src, dst, migrate.Opts{
+		PageSize: *pageSize,
+		Token:    token,
+		DryRun:   *dryRun,
+		Checkpoint: func(token string) error {
+			return writeState(*stateFile, token)
+		},
+		Progress: func(module, version string, copied bool) {
+			if copied {
+				log.Printf("copy %s@%s", module, version)
+			}
+		},
+	})
+	if err != nil {
+		log.Fatalf("migration stopped after %d copied and %d skipped versions (%s)", res.Copied, res.Skipped, err)
+	}
+	if *dryRun {
+		log.Printf("dry run: %d versions to copy, %d already copied", res.Copied, res.Skipped)
+		return
+	}
+	if err := os.Remove(*stateFile); err != nil && !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+	log.Printf("done: %d versions copied, %d already copied", res.Copied, res.Skipped)
+}
+
+func readState(name string) (string, error) {
+	b, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(b)), err
+}
+
+// writeState replaces the state file atomically,
+// so that an interruption never leaves half a token
+func writeState(name, token string) error {
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(token+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -13,6 +13,8 @@ import (
 	mw "github.com/gomods/athens/pkg/middleware"
 	"github.com/gomods/athens/pkg/module"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage/fallback"
+	"github.com/gomods/athens/pkg/storage/readonly"
 	"github.com/rs/cors"
 	"github.com/sirupsen/logrus"
 	"github.com/unrolled/secure"
@@ -33,22 +35,36 @@ func App(conf *config.Config) (*buffalo.App, error) {
 	// ENV is used to help switch settings based on where the
 	// application is being run. Default is "development".
 	ENV := conf.GoEnv
-	store, err := GetStorage(conf.Proxy.StorageType, conf.Storage)
+	logLvl, err := logrus.ParseLevel(conf.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	lggr := log.New(conf.CloudRuntime, logLvl)
+
+	if conf.Proxy.ReadOnly {
+		stopBackgroundWrites(conf.Storage)
+	}
+	store, err := GetStorage(conf.Proxy.StorageType, conf.Storage, lggr)
 	if err != nil {
 		err = fmt.Errorf("error getting storage configuration (%s)", err)
 		return nil, err
 	}
+	if fallbackType := conf.Proxy.FallbackStorageType; fallbackType != "" {
+		old, err := getBackend(fallbackType, conf.Storage, lggr)
+		if err != nil {
+			err = fmt.Errorf("error getting fallback storage configuration (%s)", err)
+			return nil, err
+		}
+		store = fallback.New(store, old)
+	}
+	if conf.Proxy.ReadOnly {
+		store = readonly.New(store)
+	}
 
 	// mount .netrc to home dir
 	// to have access to private repos.
 	initializeNETRC(conf.Proxy.NETRCPath)
 
-	logLvl, err := logrus.ParseLevel(conf.LogLevel)
-	if err != nil {
-		return nil, err
-	}
-	lggr := log.New(conf.CloudRuntime, logLvl)
-
 	bLogLvl, err := logrus.ParseLevel(conf.BuffaloLogLevel)
 	if err != nil {
 		return nil, err
@@ -117,7 +133,7 @@ func App(conf *config.Config) (*buffalo.App, error) {
 		app.Use(basicAuth(user, pass))
 	}
 
-	if err := addProxyRoutes(app, store, lggr, conf.GoBinary, conf.GoGetWorkers, conf.ProtocolWorkers); err != nil {
+	if err := addProxyRoutes(app, store, lggr, conf.GoBinary, conf.GoGetWorkers, conf.ProtocolWorkers, conf.TimeoutDuration(), conf.Proxy); err != nil {
 		err = fmt.Errorf("error adding proxy routes (%s)", err)
 		return nil, err
 	}
@@ -1,13 +1,17 @@
 package actions
 
 import (
+	"time"
+
 	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/download"
 	"github.com/gomods/athens/pkg/download/addons"
 	"github.com/gomods/athens/pkg/log"
 	"github.com/gomods/athens/pkg/module"
 	"github.com/gomods/athens/pkg/stash"
 	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/quota"
 	"github.com/spf13/afero"
 )
 
@@ -18,9 +22,17 @@ func addProxyRoutes(
 	goBin string,
 	goGetWorkers int,
 	protocolWorkers int,
+	timeout time.Duration,
+	proxyConf *config.ProxyConfig,
 ) error {
 	app.GET("/", proxyHomeHandler)
 	app.GET("/healthz", healthHandler)
+	// wrappers such as the encrypted storage pass the stats of a
+	// quota through, only register the route if there are any
+	if _, err := quota.StatsOf(s); err == nil {
+		app.GET("/storage/stats", storageStatsHandler(s, l))
+	}
+	app.GET("/catalog", catalogHandler(s, l))
 
 	// Download Protocol
 	// the download.Protocol and the stash.Stasher interfaces are composable
@@ -44,24 +56,87 @@ func addProxyRoutes(
 	// 2. The singleflight passes the stash to its parent: stashpool.
 	// 3. The stashpool manages limiting concurrent requests and passes them to stash.
 	// 4. The plain stash.New just takes a request from upstream and saves it into storage.
-	fs := afero.NewOsFs()
-	mf, err := module.NewGoGetFetcher(goBin, fs)
+	mf, lister, err := upstreams(goBin, timeout, proxyConf.UpstreamProxies)
 	if err != nil {
 		return err
 	}
+	if ttl := proxyConf.ListCacheTTL(); ttl > 0 {
+		lister = download.NewCacheLister(lister, ttl)
+	}
 
-	lister := download.NewVCSLister(goBin, fs)
 	st := stash.New(mf, s, stash.WithPool(goGetWorkers), stash.WithSingleflight)
 
 	dpOpts := &download.Opts{
-		Storage: s,
-		Stasher: st,
-		Lister:  lister,
+		Storage:            s,
+		Stasher:            st,
+		Lister:             lister,
+		ReadOnly:           proxyConf.ReadOnly,
+		Offline:            proxyConf.Offline,
+		RedirectExpiry:     proxyConf.RedirectExpiry(),
+		RedirectModInfo:    proxyConf.RedirectModInfo,
+		RedirectUnverified: proxyConf.RedirectUnverified,
+	}
+	if dpOpts.RedirectExpiry > 0 && dpOpts.RedirectUnverified {
+		l.Warnf("redirected downloads are not verified against the stored checksums")
 	}
 	dp := download.New(dpOpts, addons.WithPool(protocolWorkers))
 
 	handlerOpts := &download.HandlerOpts{Protocol: dp, Logger: l, Engine: proxy}
+	if len(proxyConf.SumDBs) > 0 {
+		handlerOpts.SumDB, err = download.NewSumDB(s, timeout, proxyConf.SumDBs...)
+		if err != nil {
+			return err
+		}
+	}
 	download.RegisterHandlers(app, handlerOpts)
 
 	return nil
 }
+
+// upstreams returns the fetcher and lister of the upstream sources:
+// the go binary and VCS when no upstream proxies are configured,
+// or else a chain of the configured proxies in which "direct"
+// stands for the go binary and VCS, and "git" for fetching
+// from and listing git repositories without the go binary.
+func upstreams(goBin string, timeout time.Duration, proxies []string) (module.Fetcher, download.UpstreamLister, error) {
+	if len(proxies) == 0 {
+		proxies = []string{"direct"}
+	}
+	fs := afero.NewOsFs()
+	var fetchers []module.Fetcher
+	var listers []download.UpstreamLister
+	for _, p := range proxies {
+		switch p {
+		case "direct":
+			f, err := module.NewGoGetFetcher(goBin, fs)
+			if err != nil {
+				return nil, nil, err
+			}
+			fetchers = append(fetchers, f)
+			listers = append(listers, download.NewVCSLister(goBin, fs))
+			continue
+		case "git":
+			f, err := module.NewGitFetcher("git", fs, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			l, err := download.NewGitLister("git", fs, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			fetchers = append(fetchers, f)
+			listers = append(listers, l)
+			continue
+		}
+		f, err := module.NewUpstreamFetcher(timeout, p)
+		if err != nil {
+			return nil, nil, err
+		}
+		fetchers = append(fetchers, f)
+		listers = append(listers, download.NewProxyLister(timeout, p))
+	}
+	if len(fetchers) == 1 {
+		return fetchers[0], listers[0], nil
+	}
+	return module.NewChainFetcher(fetchers...), download.NewChainLister(listers...), nil
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+const (
+	defaultCatalogPageSize = 1000
+	maxCatalogPageSize     = 10000
+)
+
+type catalogEntry struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+}
+
+// catalogRes is a page of the catalog. Next is the
+// token of the next page and empty on the last page.
+type catalogRes struct {
+	Modules []catalogEntry `json:"modules"`
+	Next    string         `json:"next,omitempty"`
+}
+
+// catalogHandler implements GET /catalog?token=<token>&pagesize=<n>
+func catalogHandler(s storage.Cataloger, l *log.Logger) buffalo.Handler {
+	const op errors.Op = "actions.catalogHandler"
+	return func(c buffalo.Context) error {
+		pageSize := defaultCatalogPageSize
+		if ps := c.Param("pagesize"); ps != "" {
+			n, err := strconv.Atoi(ps)
+			if err != nil || n <= 0 {
+				return c.Render(http.StatusBadRequest, proxy.JSON("pagesize must be a positive number"))
+			}
+			pageSize = n
+		}
+		if pageSize > maxCatalogPageSize {
+			pageSize = maxCatalogPageSize
+		}
+
+		page, next, err := s.Catalog(c, c.Param("token"), pageSize)
+		if err != nil {
+			err = errors.E(op, err)
+			l.SystemErr(err)
+			return c.Render(errors.Kind(err), proxy.JSON(errors.KindText(err)))
+		}
+
+		res := catalogRes{Modules: make([]catalogEntry, 0, len(page)), Next: next}
+		for _, p := range page {
+			res.Modules = append(res.Modules, catalogEntry{Module: p.Module, Version: p.Version})
+		}
+		return c.Render(http.StatusOK, proxy.JSON(res))
+	}
+}
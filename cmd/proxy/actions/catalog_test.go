@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func getCatalog(t *testing.T, app *buffalo.App, query string) (int, catalogRes) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("GET", "/catalog"+query, nil)
+	require.NoError(t, err)
+	app.ServeHTTP(w, r)
+	var res catalogRes
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	}
+	return w.Code, res
+}
+
+func TestCatalogHandler(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	for _, v := range []string{"v1.0.0", "v1.1.0", "v1.2.0"} {
+		err := s.Save(context.Background(), "github.com/athens-artifacts/catalog", v, nil, bytes.NewReader(nil), []byte("{}"))
+		require.NoError(t, err)
+	}
+
+	app := buffalo.New(buffalo.Options{})
+	app.GET("/catalog", catalogHandler(s, log.New("none", logrus.PanicLevel)))
+
+	code, first := getCatalog(t, app, "?pagesize=2")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []catalogEntry{
+		{Module: "github.com/athens-artifacts/catalog", Version: "v1.0.0"},
+		{Module: "github.com/athens-artifacts/catalog", Version: "v1.1.0"},
+	}, first.Modules)
+	require.NotEmpty(t, first.Next)
+
+	code, second := getCatalog(t, app, "?pagesize=2&token="+first.Next)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []catalogEntry{
+		{Module: "github.com/athens-artifacts/catalog", Version: "v1.2.0"},
+	}, second.Modules)
+	require.Empty(t, second.Next)
+
+	code, _ = getCatalog(t, app, "?pagesize=none")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = getCatalog(t, app, "?token=invalid")
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -3,24 +3,72 @@ package actions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
 	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/azurecdn"
+	"github.com/gomods/athens/pkg/storage/cas"
+	"github.com/gomods/athens/pkg/storage/encrypted"
 	"github.com/gomods/athens/pkg/storage/fs"
 	"github.com/gomods/athens/pkg/storage/gcp"
 	"github.com/gomods/athens/pkg/storage/mem"
 	"github.com/gomods/athens/pkg/storage/minio"
 	"github.com/gomods/athens/pkg/storage/mongo"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/gomods/athens/pkg/storage/replicated"
+	"github.com/gomods/athens/pkg/storage/s3"
+	"github.com/gomods/athens/pkg/storage/tiered"
+	"github.com/gomods/athens/pkg/storage/webdav"
 	"github.com/spf13/afero"
 )
 
-// GetStorage returns storage backend based on env configuration
-func GetStorage(storageType string, storageConfig *config.StorageConfig) (storage.Backend, error) {
+// GetStorage returns storage backend based on env configuration.
+// The files of module versions are encrypted if a keyring is configured,
+// and remote storage backends are put behind a disk cache if one is configured.
+// Background work of the backend, such as the repairs of replicated
+// storage and the collection of unused files of content addressed
+// storage, logs to lggr.
+func GetStorage(storageType string, storageConfig *config.StorageConfig, lggr log.Entry) (storage.Backend, error) {
 	const op errors.Op = "actions.GetStorage"
+	s, err := getBackend(storageType, storageConfig, lggr)
+	if err != nil {
+		return nil, err
+	}
+	if encConf := storageConfig.Encryption; encConf != nil {
+		keys, err := encrypted.ReadKeyring(encConf.KeyringPath)
+		if err != nil {
+			errStr := fmt.Sprintf("could not read encryption keyring (%s)", err)
+			return nil, errors.E(op, errStr)
+		}
+		s = encrypted.New(s, keys)
+	}
+	cacheConf := storageConfig.DiskCache
+	if cacheConf == nil || storageType == "memory" || storageType == "disk" {
+		return s, nil
+	}
+	cached, err := tiered.New(cacheConf.RootPath, afero.NewOsFs(), cacheConf.MaxBytes(), s)
+	if err != nil {
+		errStr := fmt.Sprintf("could not create disk cache (%s)", err)
+		return nil, errors.E(op, errStr)
+	}
+	return cached, nil
+}
+
+func getBackend(storageType string, storageConfig *config.StorageConfig, lggr log.Entry) (storage.Backend, error) {
+	const op errors.Op = "actions.getBackend"
 	switch storageType {
 	case "memory":
-		return mem.NewStorage()
+		s, err := mem.NewStorage()
+		if err != nil {
+			return nil, err
+		}
+		if q := storageConfig.Quota; q != nil {
+			return quota.New(s, q.MaxBytes(), q.Pinned, nil), nil
+		}
+		return s, nil
 	case "mongo":
 		if storageConfig.Mongo == nil {
 			return nil, errors.E(op, "Invalid Mongo Storage Configuration")
@@ -31,16 +79,43 @@ func GetStorage(storageType string, storageConfig *config.StorageConfig) (storag
 			return nil, errors.E(op, "Invalid Disk Storage Configuration")
 		}
 		rootLocation := storageConfig.Disk.RootPath
-		s, err := fs.NewStorage(rootLocation, afero.NewOsFs())
+		osFs := afero.NewOsFs()
+		if storageConfig.Disk.ContentAddressed {
+			if storageConfig.Quota != nil {
+				return nil, errors.E(op, "a quota can not be set for content-addressed disk storage")
+			}
+			blobs, err := fs.NewBlobs(rootLocation, osFs)
+			if err != nil {
+				errStr := fmt.Sprintf("could not create new storage from os fs (%s)", err)
+				return nil, errors.E(op, errStr)
+			}
+			return contentAddressed(blobs, storageConfig.Disk.GCIntervalSec, lggr), nil
+		}
+		s, err := fs.NewStorage(rootLocation, osFs)
 		if err != nil {
 			errStr := fmt.Sprintf("could not create new storage from os fs (%s)", err)
 			return nil, errors.E(op, errStr)
 		}
+		if q := storageConfig.Quota; q != nil {
+			stored, err := fs.Versions(rootLocation, osFs)
+			if err != nil {
+				errStr := fmt.Sprintf("could not read stored versions for the quota (%s)", err)
+				return nil, errors.E(op, errStr)
+			}
+			return quota.New(s, q.MaxBytes(), q.Pinned, stored), nil
+		}
 		return s, nil
 	case "minio":
 		if storageConfig.Minio == nil {
 			return nil, errors.E(op, "Invalid Minio Storage Configuration")
 		}
+		if storageConfig.Minio.ContentAddressed {
+			blobs, err := minio.NewBlobs(storageConfig.Minio)
+			if err != nil {
+				return nil, err
+			}
+			return contentAddressed(blobs, storageConfig.Minio.GCIntervalSec, lggr), nil
+		}
 		return minio.NewStorage(storageConfig.Minio)
 	case "gcp":
 		if storageConfig.GCP == nil {
@@ -49,8 +124,91 @@ func GetStorage(storageType string, storageConfig *config.StorageConfig) (storag
 		if storageConfig.CDN == nil {
 			return nil, errors.E(op, "Invalid CDN Storage Configuration")
 		}
+		if storageConfig.GCP.ContentAddressed {
+			blobs, err := gcp.NewBlobs(context.Background(), storageConfig.GCP)
+			if err != nil {
+				return nil, err
+			}
+			return contentAddressed(blobs, storageConfig.GCP.GCIntervalSec, lggr), nil
+		}
 		return gcp.New(context.Background(), storageConfig.GCP, storageConfig.CDN)
+	case "s3":
+		if storageConfig.S3 == nil {
+			return nil, errors.E(op, "Invalid S3 Storage Configuration")
+		}
+		if storageConfig.CDN == nil {
+			return nil, errors.E(op, "Invalid CDN Storage Configuration")
+		}
+		return s3.New(storageConfig.S3, storageConfig.CDN)
+	case "azureblob":
+		if storageConfig.AzureBlob == nil {
+			return nil, errors.E(op, "Invalid AzureBlob Storage Configuration")
+		}
+		if storageConfig.CDN == nil {
+			return nil, errors.E(op, "Invalid CDN Storage Configuration")
+		}
+		return azurecdn.New(storageConfig.AzureBlob, storageConfig.CDN)
+	case "webdav":
+		if storageConfig.WebDAV == nil {
+			return nil, errors.E(op, "Invalid WebDAV Storage Configuration")
+		}
+		return webdav.New(storageConfig.WebDAV)
+	case "replicated":
+		conf := storageConfig.Replicated
+		if conf == nil {
+			return nil, errors.E(op, "Invalid Replicated Storage Configuration")
+		}
+		replicas := make([]storage.Backend, 0, len(conf.Backends))
+		for _, replicaType := range conf.Backends {
+			if replicaType == "replicated" {
+				return nil, errors.E(op, "replicated storage cannot be its own replica")
+			}
+			r, err := getBackend(replicaType, storageConfig, lggr)
+			if err != nil {
+				errStr := fmt.Sprintf("could not create %s replica (%s)", replicaType, err)
+				return nil, errors.E(op, errStr)
+			}
+			replicas = append(replicas, r)
+		}
+		s, err := replicated.New(conf.WriteQuorum, replicas...)
+		if err != nil {
+			return nil, err
+		}
+		if conf.RepairIntervalSec > 0 {
+			interval := time.Duration(conf.RepairIntervalSec) * time.Second
+			go s.RepairEvery(context.Background(), interval, lggr)
+		}
+		return s, nil
 	default:
 		return nil, fmt.Errorf("storage type %s is unknown", storageType)
 	}
 }
+
+// stopBackgroundWrites turns off the storage jobs that write to the
+// backends on their own, so that a read-only proxy changes nothing:
+// repairs of replicas and garbage collections of blobs
+func stopBackgroundWrites(conf *config.StorageConfig) {
+	if conf.Replicated != nil {
+		conf.Replicated.RepairIntervalSec = 0
+	}
+	if conf.Disk != nil {
+		conf.Disk.GCIntervalSec = 0
+	}
+	if conf.Minio != nil {
+		conf.Minio.GCIntervalSec = 0
+	}
+	if conf.GCP != nil {
+		conf.GCP.GCIntervalSec = 0
+	}
+}
+
+// contentAddressed returns a content addressed storage of blobs that
+// removes the blobs no module version refers to every gcIntervalSec
+func contentAddressed(blobs cas.Blobs, gcIntervalSec int, lggr log.Entry) *cas.Storage {
+	s := cas.New(blobs)
+	if gcIntervalSec > 0 {
+		interval := time.Duration(gcIntervalSec) * time.Second
+		go s.GCEvery(context.Background(), interval, lggr)
+	}
+	return s
+}
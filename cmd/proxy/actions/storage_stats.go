@@ -0,0 +1,23 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/quota"
+)
+
+func storageStatsHandler(s storage.Backend, l *log.Logger) buffalo.Handler {
+	const op errors.Op = "actions.storageStatsHandler"
+	return func(c buffalo.Context) error {
+		stats, err := quota.StatsOf(s)
+		if err != nil {
+			l.SystemErr(errors.E(op, err))
+			return c.Render(errors.Kind(err), proxy.JSON(errors.KindText(err)))
+		}
+		return c.Render(http.StatusOK, proxy.JSON(stats))
+	}
+}
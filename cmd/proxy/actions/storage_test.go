@@ -0,0 +1,95 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentAddressedGC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "athens-cas")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	conf := &config.StorageConfig{
+		Disk: &config.DiskConfig{RootPath: dir, ContentAddressed: true, GCIntervalSec: 1},
+	}
+	s, err := GetStorage("disk", conf, log.New("none", logrus.PanicLevel))
+	require.NoError(t, err)
+
+	const mod = "github.com/athens-artifacts/gc"
+	ctx := context.Background()
+	for _, v := range []string{"v1.0.0", "v1.0.1"} {
+		err := s.Save(ctx, mod, v, []byte("module "+mod), bytes.NewReader([]byte("zip")), []byte(v))
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.Delete(ctx, mod, "v1.0.0"))
+	// the .info file of v1.0.0 is the only one no version refers to
+	blobs := func() int {
+		n := 0
+		filepath.Walk(filepath.Join(dir, "blobs"), func(p string, fi os.FileInfo, err error) error {
+			if err == nil && !fi.IsDir() {
+				n++
+			}
+			return nil
+		})
+		return n
+	}
+	require.Equal(t, 4, blobs())
+	deadline := time.Now().Add(5 * time.Second)
+	for blobs() > 3 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Equal(t, 3, blobs())
+}
+
+func TestReadOnlyStopsGC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "athens-cas")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	conf := &config.StorageConfig{
+		Disk:       &config.DiskConfig{RootPath: dir, ContentAddressed: true, GCIntervalSec: 1},
+		Minio:      &config.MinioConfig{GCIntervalSec: 1},
+		GCP:        &config.GCPConfig{GCIntervalSec: 1},
+		Replicated: &config.ReplicatedConfig{RepairIntervalSec: 1},
+	}
+	stopBackgroundWrites(conf)
+	require.Zero(t, conf.Disk.GCIntervalSec)
+	require.Zero(t, conf.Minio.GCIntervalSec)
+	require.Zero(t, conf.GCP.GCIntervalSec)
+	require.Zero(t, conf.Replicated.RepairIntervalSec)
+
+	s, err := GetStorage("disk", conf, log.New("none", logrus.PanicLevel))
+	require.NoError(t, err)
+	const mod = "github.com/athens-artifacts/gc"
+	ctx := context.Background()
+	require.NoError(t, s.Save(ctx, mod, "v1.0.0", []byte("module "+mod), bytes.NewReader([]byte("zip")), []byte("v1.0.0")))
+	require.NoError(t, s.Delete(ctx, mod, "v1.0.0"))
+	// the blobs of the deleted version outlive a GC interval
+	time.Sleep(1500 * time.Millisecond)
+	n := 0
+	filepath.Walk(filepath.Join(dir, "blobs"), func(p string, fi os.FileInfo, err error) error {
+		if err == nil && !fi.IsDir() {
+			n++
+		}
+		return nil
+	})
+	require.Equal(t, 3, n)
+}
+
+func TestQuotaWithContentAddressedDisk(t *testing.T) {
+	conf := &config.StorageConfig{
+		Disk:  &config.DiskConfig{RootPath: os.TempDir(), ContentAddressed: true},
+		Quota: &config.QuotaConfig{MaxSizeMB: 1},
+	}
+	_, err := GetStorage("disk", conf, log.New("none", logrus.PanicLevel))
+	require.Error(t, err)
+}
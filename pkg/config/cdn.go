@@ -1,6 +1,10 @@
 package config
 
-import "net/url"
+import (
+	"net/url"
+	"path"
+	"strings"
+)
 
 // CDNConfig specifies the properties required to use a CDN as the storage backend
 type CDNConfig struct {
@@ -22,3 +26,22 @@ func (c *CDNConfig) CDNEndpointWithDefault(value *url.URL) *url.URL {
 	}
 	return uri
 }
+
+// CDNURL returns the URL of the file at path on the CDN endpoint
+// and false if no endpoint is set. An endpoint without a scheme,
+// such as cdn.example.com, is served over https.
+func (c *CDNConfig) CDNURL(filePath string) (string, bool) {
+	if c.Endpoint == "" {
+		return "", false
+	}
+	rawURI := c.Endpoint
+	if !strings.Contains(rawURI, "://") {
+		rawURI = "https://" + rawURI
+	}
+	uri, err := url.Parse(rawURI)
+	if err != nil {
+		return "", false
+	}
+	uri.Path = path.Join("/", uri.Path, filePath)
+	return uri.String(), true
+}
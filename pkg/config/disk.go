@@ -2,5 +2,7 @@ package config
 
 // DiskConfig specifies the properties required to use Disk as the storage backend
 type DiskConfig struct {
-	RootPath string `validate:"required" envconfig:"ATHENS_DISK_STORAGE_ROOT"`
+	RootPath         string `validate:"required" envconfig:"ATHENS_DISK_STORAGE_ROOT"`
+	ContentAddressed bool   `envconfig:"ATHENS_DISK_CONTENT_ADDRESSED"`
+	GCIntervalSec    int    `envconfig:"ATHENS_DISK_GC_INTERVAL_SEC"`
 }
@@ -0,0 +1,19 @@
+package config
+
+// defaultDiskCacheMaxSizeMB is the size of the disk cache if none is configured
+const defaultDiskCacheMaxSizeMB = 1024
+
+// DiskCacheConfig specifies the properties required to cache
+// module versions on the local disk in front of a remote storage backend
+type DiskCacheConfig struct {
+	RootPath  string `validate:"required" envconfig:"ATHENS_DISK_CACHE_ROOT"`
+	MaxSizeMB int64  `envconfig:"ATHENS_DISK_CACHE_MAX_SIZE_MB"`
+}
+
+// MaxBytes returns the maximum size of the cache in bytes
+func (d *DiskCacheConfig) MaxBytes() int64 {
+	if d.MaxSizeMB <= 0 {
+		return defaultDiskCacheMaxSizeMB * 1024 * 1024
+	}
+	return d.MaxSizeMB * 1024 * 1024
+}
@@ -0,0 +1,7 @@
+package config
+
+// EncryptionConfig specifies the keyring that the files
+// of module versions are encrypted with in storage
+type EncryptionConfig struct {
+	KeyringPath string `validate:"required" envconfig:"ATHENS_ENCRYPTION_KEYRING"`
+}
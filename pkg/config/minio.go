@@ -3,9 +3,11 @@ package config
 // MinioConfig specifies the properties required to use Minio as the storage backend
 type MinioConfig struct {
 	TimeoutConf
-	Endpoint  string `validate:"required" envconfig:"ATHENS_MINIO_ENDPOINT"`
-	Key       string `validate:"required" envconfig:"ATHENS_MINIO_ACCESS_KEY_ID"`
-	Secret    string `validate:"required" envconfig:"ATHENS_MINIO_SECRET_ACCESS_KEY"`
-	Bucket    string `validate:"required" envconfig:"ATHENS_MINIO_BUCKET_NAME"`
-	EnableSSL bool   `envconfig:"ATHENS_MINIO_USE_SSL"`
+	Endpoint         string `validate:"required" envconfig:"ATHENS_MINIO_ENDPOINT"`
+	Key              string `validate:"required" envconfig:"ATHENS_MINIO_ACCESS_KEY_ID"`
+	Secret           string `validate:"required" envconfig:"ATHENS_MINIO_SECRET_ACCESS_KEY"`
+	Bucket           string `validate:"required" envconfig:"ATHENS_MINIO_BUCKET_NAME"`
+	EnableSSL        bool   `envconfig:"ATHENS_MINIO_USE_SSL"`
+	ContentAddressed bool   `envconfig:"ATHENS_MINIO_CONTENT_ADDRESSED"`
+	GCIntervalSec    int    `envconfig:"ATHENS_MINIO_GC_INTERVAL_SEC"`
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -30,7 +31,11 @@ func compareConfigs(parsedConf *Config, expConf *Config, t *testing.T) {
 }
 
 func compareStorageConfigs(parsedStorage *StorageConfig, expStorage *StorageConfig, t *testing.T) {
-	eq := cmp.Equal(parsedStorage.CDN, expStorage.CDN)
+	eq := cmp.Equal(parsedStorage.AzureBlob, expStorage.AzureBlob)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.AzureBlob, parsedStorage.AzureBlob)
+	}
+	eq = cmp.Equal(parsedStorage.CDN, expStorage.CDN)
 	if !eq {
 		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.CDN, parsedStorage.CDN)
 	}
@@ -46,16 +51,42 @@ func compareStorageConfigs(parsedStorage *StorageConfig, expStorage *StorageConf
 	if !eq {
 		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.Disk, parsedStorage.Disk)
 	}
+	eq = cmp.Equal(parsedStorage.DiskCache, expStorage.DiskCache)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.DiskCache, parsedStorage.DiskCache)
+	}
+	eq = cmp.Equal(parsedStorage.Encryption, expStorage.Encryption)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.Encryption, parsedStorage.Encryption)
+	}
 	eq = cmp.Equal(parsedStorage.GCP, expStorage.GCP)
 	if !eq {
 		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.GCP, parsedStorage.GCP)
 	}
+	eq = cmp.Equal(parsedStorage.Quota, expStorage.Quota)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.Quota, parsedStorage.Quota)
+	}
+
+	eq = cmp.Equal(parsedStorage.Replicated, expStorage.Replicated)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.Replicated, parsedStorage.Replicated)
+	}
+	eq = cmp.Equal(parsedStorage.S3, expStorage.S3)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.S3, parsedStorage.S3)
+	}
+	eq = cmp.Equal(parsedStorage.WebDAV, expStorage.WebDAV)
+	if !eq {
+		t.Errorf("Parsed Example Storage configuration did not match expected values. Expected: %+v. Actual: %+v", expStorage.WebDAV, parsedStorage.WebDAV)
+	}
 }
 
 func TestEnvOverrides(t *testing.T) {
 
 	expProxy := ProxyConfig{
 		StorageType:           "minio",
+		FallbackStorageType:   "mongo",
 		OlympusGlobalEndpoint: "mytikas.gomods.io",
 		Port:                  ":7000",
 		FilterOff:             false,
@@ -65,6 +96,15 @@ func TestEnvOverrides(t *testing.T) {
 		ValidatorHook:         "testhook.io",
 		PathPrefix:            "prefix",
 		NETRCPath:             "/test/path",
+		ReadOnly:              true,
+		Offline:               true,
+		Redirect:              true,
+		RedirectModInfo:       true,
+		RedirectExpirySec:     300,
+		RedirectUnverified:    true,
+		UpstreamProxies:       []string{"https://proxy.example.com", "https://proxy.golang.org", "direct"},
+		ListCacheTTLSec:       30,
+		SumDBs:                []string{"https://sum.golang.org", "https://sum.example.com/db"},
 	}
 
 	expOlympus := OlympusConfig{
@@ -116,6 +156,14 @@ func TestStorageEnvOverrides(t *testing.T) {
 
 	globalTimeout := 300
 	expStorage := &StorageConfig{
+		AzureBlob: &AzureBlobConfig{
+			AccountName:   "azureAccountName",
+			AccountKey:    "azureAccountKey",
+			ContainerName: "azureContainerName",
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
 		CDN: &CDNConfig{
 			Endpoint: "cdnEndpoint",
 			TimeoutConf: TimeoutConf{
@@ -123,21 +171,34 @@ func TestStorageEnvOverrides(t *testing.T) {
 			},
 		},
 		Disk: &DiskConfig{
-			RootPath: "/my/root/path",
+			RootPath:         "/my/root/path",
+			ContentAddressed: true,
+			GCIntervalSec:    3600,
+		},
+		DiskCache: &DiskCacheConfig{
+			RootPath:  "/my/cache/path",
+			MaxSizeMB: 512,
+		},
+		Encryption: &EncryptionConfig{
+			KeyringPath: "/my/keyring.json",
 		},
 		GCP: &GCPConfig{
-			ProjectID: "gcpproject",
-			Bucket:    "gcpbucket",
+			ProjectID:        "gcpproject",
+			Bucket:           "gcpbucket",
+			ContentAddressed: true,
+			GCIntervalSec:    3600,
 			TimeoutConf: TimeoutConf{
 				Timeout: globalTimeout,
 			},
 		},
 		Minio: &MinioConfig{
-			Endpoint:  "minioEndpoint",
-			Key:       "minioKey",
-			Secret:    "minioSecret",
-			EnableSSL: false,
-			Bucket:    "minioBucket",
+			Endpoint:         "minioEndpoint",
+			Key:              "minioKey",
+			Secret:           "minioSecret",
+			EnableSSL:        false,
+			Bucket:           "minioBucket",
+			ContentAddressed: true,
+			GCIntervalSec:    3600,
 			TimeoutConf: TimeoutConf{
 				Timeout: globalTimeout,
 			},
@@ -149,6 +210,34 @@ func TestStorageEnvOverrides(t *testing.T) {
 				Timeout: globalTimeout,
 			},
 		},
+		Quota: &QuotaConfig{
+			MaxSizeMB: 2048,
+			Pinned:    []string{"github.com/my-org", "gopkg.in/yaml.v2"},
+		},
+		Replicated: &ReplicatedConfig{
+			Backends:          []string{"disk", "minio"},
+			WriteQuorum:       1,
+			RepairIntervalSec: 600,
+		},
+		S3: &S3Config{
+			Region: "s3Region",
+			Key:    "s3Key",
+			Secret: "s3Secret",
+			Token:  "s3Token",
+			Bucket: "s3Bucket",
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
+		WebDAV: &WebDAVConfig{
+			URL:      "https://dav.example.com/gomods",
+			Username: "davUser",
+			Password: "davPassword",
+			Headers:  map[string]string{"X-Api-Key": "davKey", "X-Team": "athens"},
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
 	}
 	envVars := getEnvMap(&Config{Storage: expStorage})
 	envVarBackup := map[string]string{}
@@ -178,13 +267,20 @@ func TestParseExampleConfig(t *testing.T) {
 		Proxy:   &ProxyConfig{},
 		Olympus: &OlympusConfig{},
 		Storage: &StorageConfig{
-			CDN:  &CDNConfig{},
-			Disk: &DiskConfig{},
-			GCP:  &GCPConfig{},
+			AzureBlob:  &AzureBlobConfig{},
+			CDN:        &CDNConfig{},
+			Disk:       &DiskConfig{},
+			DiskCache:  &DiskCacheConfig{},
+			Encryption: &EncryptionConfig{},
+			GCP:        &GCPConfig{},
 			Minio: &MinioConfig{
 				EnableSSL: false,
 			},
-			Mongo: &MongoConfig{},
+			Mongo:      &MongoConfig{},
+			Quota:      &QuotaConfig{},
+			Replicated: &ReplicatedConfig{},
+			S3:         &S3Config{},
+			WebDAV:     &WebDAVConfig{},
 		},
 	}
 	// unset all environment variables
@@ -205,6 +301,10 @@ func TestParseExampleConfig(t *testing.T) {
 		FilterOff:             true,
 		BasicAuthUser:         "",
 		BasicAuthPass:         "",
+		RedirectExpirySec:     900,
+		UpstreamProxies:       []string{},
+		ListCacheTTLSec:       60,
+		SumDBs:                []string{"https://sum.golang.org"},
 	}
 
 	expOlympus := &OlympusConfig{
@@ -215,6 +315,14 @@ func TestParseExampleConfig(t *testing.T) {
 	}
 
 	expStorage := &StorageConfig{
+		AzureBlob: &AzureBlobConfig{
+			AccountName:   "MY_AZURE_BLOB_ACCOUNT_NAME",
+			AccountKey:    "MY_AZURE_BLOB_ACCOUNT_KEY",
+			ContainerName: "MY_AZURE_BLOB_CONTAINER_NAME",
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
 		CDN: &CDNConfig{
 			Endpoint: "cdn.example.com",
 			TimeoutConf: TimeoutConf{
@@ -248,6 +356,25 @@ func TestParseExampleConfig(t *testing.T) {
 				Timeout: globalTimeout,
 			},
 		},
+		S3: &S3Config{
+			Region: "MY_AWS_REGION",
+			Key:    "",
+			Secret: "",
+			Token:  "",
+			Bucket: "MY_S3_BUCKET_NAME",
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
+		WebDAV: &WebDAVConfig{
+			URL:      "http://127.0.0.1:8080/gomods",
+			Username: "",
+			Password: "",
+			Headers:  map[string]string{},
+			TimeoutConf: TimeoutConf{
+				Timeout: globalTimeout,
+			},
+		},
 	}
 
 	expConf := &Config{
@@ -357,6 +484,7 @@ func getEnvMap(config *Config) map[string]string {
 	proxy := config.Proxy
 	if proxy != nil {
 		envVars["ATHENS_STORAGE_TYPE"] = proxy.StorageType
+		envVars["ATHENS_FALLBACK_STORAGE_TYPE"] = proxy.FallbackStorageType
 		envVars["OLYMPUS_GLOBAL_ENDPOINT"] = proxy.OlympusGlobalEndpoint
 		envVars["PORT"] = proxy.Port
 		envVars["PROXY_FILTER_OFF"] = strconv.FormatBool(proxy.FilterOff)
@@ -366,6 +494,15 @@ func getEnvMap(config *Config) map[string]string {
 		envVars["ATHENS_PROXY_VALIDATOR"] = proxy.ValidatorHook
 		envVars["ATHENS_PATH_PREFIX"] = proxy.PathPrefix
 		envVars["ATHENS_NETRC_PATH"] = proxy.NETRCPath
+		envVars["ATHENS_PROXY_READ_ONLY"] = strconv.FormatBool(proxy.ReadOnly)
+		envVars["ATHENS_PROXY_OFFLINE"] = strconv.FormatBool(proxy.Offline)
+		envVars["ATHENS_PROXY_REDIRECT"] = strconv.FormatBool(proxy.Redirect)
+		envVars["ATHENS_PROXY_REDIRECT_MOD_INFO"] = strconv.FormatBool(proxy.RedirectModInfo)
+		envVars["ATHENS_PROXY_REDIRECT_EXPIRY_SEC"] = strconv.Itoa(proxy.RedirectExpirySec)
+		envVars["ATHENS_PROXY_REDIRECT_UNVERIFIED"] = strconv.FormatBool(proxy.RedirectUnverified)
+		envVars["ATHENS_UPSTREAM_PROXIES"] = strings.Join(proxy.UpstreamProxies, ",")
+		envVars["ATHENS_LIST_CACHE_TTL_SEC"] = strconv.Itoa(proxy.ListCacheTTLSec)
+		envVars["ATHENS_SUM_DBS"] = strings.Join(proxy.SumDBs, ",")
 	}
 
 	olympus := config.Olympus
@@ -376,15 +513,31 @@ func getEnvMap(config *Config) map[string]string {
 
 	storage := config.Storage
 	if storage != nil {
+		if storage.AzureBlob != nil {
+			envVars["ATHENS_AZURE_ACCOUNT_NAME"] = storage.AzureBlob.AccountName
+			envVars["ATHENS_AZURE_ACCOUNT_KEY"] = storage.AzureBlob.AccountKey
+			envVars["ATHENS_AZURE_CONTAINER_NAME"] = storage.AzureBlob.ContainerName
+		}
 		if storage.CDN != nil {
 			envVars["CDN_ENDPOINT"] = storage.CDN.Endpoint
 		}
 		if storage.Disk != nil {
 			envVars["ATHENS_DISK_STORAGE_ROOT"] = storage.Disk.RootPath
+			envVars["ATHENS_DISK_CONTENT_ADDRESSED"] = strconv.FormatBool(storage.Disk.ContentAddressed)
+			envVars["ATHENS_DISK_GC_INTERVAL_SEC"] = strconv.Itoa(storage.Disk.GCIntervalSec)
+		}
+		if storage.DiskCache != nil {
+			envVars["ATHENS_DISK_CACHE_ROOT"] = storage.DiskCache.RootPath
+			envVars["ATHENS_DISK_CACHE_MAX_SIZE_MB"] = strconv.FormatInt(storage.DiskCache.MaxSizeMB, 10)
+		}
+		if storage.Encryption != nil {
+			envVars["ATHENS_ENCRYPTION_KEYRING"] = storage.Encryption.KeyringPath
 		}
 		if storage.GCP != nil {
 			envVars["GOOGLE_CLOUD_PROJECT"] = storage.GCP.ProjectID
 			envVars["ATHENS_STORAGE_GCP_BUCKET"] = storage.GCP.Bucket
+			envVars["ATHENS_STORAGE_GCP_CONTENT_ADDRESSED"] = strconv.FormatBool(storage.GCP.ContentAddressed)
+			envVars["ATHENS_STORAGE_GCP_GC_INTERVAL_SEC"] = strconv.Itoa(storage.GCP.GCIntervalSec)
 		}
 		if storage.Minio != nil {
 			envVars["ATHENS_MINIO_ENDPOINT"] = storage.Minio.Endpoint
@@ -392,11 +545,39 @@ func getEnvMap(config *Config) map[string]string {
 			envVars["ATHENS_MINIO_SECRET_ACCESS_KEY"] = storage.Minio.Secret
 			envVars["ATHENS_MINIO_USE_SSL"] = strconv.FormatBool(storage.Minio.EnableSSL)
 			envVars["ATHENS_MINIO_BUCKET_NAME"] = storage.Minio.Bucket
+			envVars["ATHENS_MINIO_CONTENT_ADDRESSED"] = strconv.FormatBool(storage.Minio.ContentAddressed)
+			envVars["ATHENS_MINIO_GC_INTERVAL_SEC"] = strconv.Itoa(storage.Minio.GCIntervalSec)
 		}
 		if storage.Mongo != nil {
 			envVars["ATHENS_MONGO_STORAGE_URL"] = storage.Mongo.URL
 			envVars["ATHENS_MONGO_CERT_PATH"] = storage.Mongo.CertPath
 		}
+		if storage.Quota != nil {
+			envVars["ATHENS_QUOTA_MAX_SIZE_MB"] = strconv.FormatInt(storage.Quota.MaxSizeMB, 10)
+			envVars["ATHENS_QUOTA_PINNED"] = strings.Join(storage.Quota.Pinned, ",")
+		}
+		if storage.Replicated != nil {
+			envVars["ATHENS_REPLICATED_BACKENDS"] = strings.Join(storage.Replicated.Backends, ",")
+			envVars["ATHENS_REPLICATED_WRITE_QUORUM"] = strconv.Itoa(storage.Replicated.WriteQuorum)
+			envVars["ATHENS_REPLICATED_REPAIR_INTERVAL_SEC"] = strconv.Itoa(storage.Replicated.RepairIntervalSec)
+		}
+		if storage.S3 != nil {
+			envVars["AWS_REGION"] = storage.S3.Region
+			envVars["AWS_ACCESS_KEY_ID"] = storage.S3.Key
+			envVars["AWS_SECRET_ACCESS_KEY"] = storage.S3.Secret
+			envVars["AWS_SESSION_TOKEN"] = storage.S3.Token
+			envVars["ATHENS_S3_BUCKET_NAME"] = storage.S3.Bucket
+		}
+		if storage.WebDAV != nil {
+			envVars["ATHENS_WEBDAV_URL"] = storage.WebDAV.URL
+			envVars["ATHENS_WEBDAV_USERNAME"] = storage.WebDAV.Username
+			envVars["ATHENS_WEBDAV_PASSWORD"] = storage.WebDAV.Password
+			headers := []string{}
+			for k, v := range storage.WebDAV.Headers {
+				headers = append(headers, k+":"+v)
+			}
+			envVars["ATHENS_WEBDAV_HEADERS"] = strings.Join(headers, ",")
+		}
 	}
 	return envVars
 }
@@ -1,17 +1,29 @@
 package config
 
+import "time"
+
 // ProxyConfig specifies the properties required to run the proxy
 type ProxyConfig struct {
-	StorageType           string `validate:"required" envconfig:"ATHENS_STORAGE_TYPE"`
-	OlympusGlobalEndpoint string `envconfig:"OLYMPUS_GLOBAL_ENDPOINT"`
-	Port                  string `validate:"required" envconfig:"PORT"`
-	FilterOff             bool   `validate:"required" envconfig:"PROXY_FILTER_OFF"`
-	BasicAuthUser         string `envconfig:"BASIC_AUTH_USER"`
-	BasicAuthPass         string `envconfig:"BASIC_AUTH_PASS"`
-	ForceSSL              bool   `envconfig:"PROXY_FORCE_SSL"`
-	ValidatorHook         string `envconfig:"ATHENS_PROXY_VALIDATOR"`
-	PathPrefix            string `envconfig:"ATHENS_PATH_PREFIX"`
-	NETRCPath             string `envconfig:"ATHENS_NETRC_PATH"`
+	StorageType           string   `validate:"required" envconfig:"ATHENS_STORAGE_TYPE"`
+	FallbackStorageType   string   `envconfig:"ATHENS_FALLBACK_STORAGE_TYPE"`
+	OlympusGlobalEndpoint string   `envconfig:"OLYMPUS_GLOBAL_ENDPOINT"`
+	Port                  string   `validate:"required" envconfig:"PORT"`
+	FilterOff             bool     `validate:"required" envconfig:"PROXY_FILTER_OFF"`
+	BasicAuthUser         string   `envconfig:"BASIC_AUTH_USER"`
+	BasicAuthPass         string   `envconfig:"BASIC_AUTH_PASS"`
+	ForceSSL              bool     `envconfig:"PROXY_FORCE_SSL"`
+	ValidatorHook         string   `envconfig:"ATHENS_PROXY_VALIDATOR"`
+	PathPrefix            string   `envconfig:"ATHENS_PATH_PREFIX"`
+	NETRCPath             string   `envconfig:"ATHENS_NETRC_PATH"`
+	ReadOnly              bool     `envconfig:"ATHENS_PROXY_READ_ONLY"`
+	Offline               bool     `envconfig:"ATHENS_PROXY_OFFLINE"`
+	Redirect              bool     `envconfig:"ATHENS_PROXY_REDIRECT"`
+	RedirectModInfo       bool     `envconfig:"ATHENS_PROXY_REDIRECT_MOD_INFO"`
+	RedirectExpirySec     int      `envconfig:"ATHENS_PROXY_REDIRECT_EXPIRY_SEC"`
+	RedirectUnverified    bool     `envconfig:"ATHENS_PROXY_REDIRECT_UNVERIFIED"`
+	UpstreamProxies       []string `envconfig:"ATHENS_UPSTREAM_PROXIES"`
+	ListCacheTTLSec       int      `envconfig:"ATHENS_LIST_CACHE_TTL_SEC"`
+	SumDBs                []string `envconfig:"ATHENS_SUM_DBS"`
 }
 
 // BasicAuth returns BasicAuthUser and BasicAuthPassword
@@ -22,3 +34,28 @@ func (p *ProxyConfig) BasicAuth() (user, pass string, ok bool) {
 	ok = user != "" && pass != ""
 	return user, pass, ok
 }
+
+// RedirectExpiry returns how long the URLs that downloads are redirected
+// to are valid, or 0 if downloads are not redirected. It defaults to 15 minutes.
+func (p *ProxyConfig) RedirectExpiry() time.Duration {
+	if !p.Redirect {
+		return 0
+	}
+	if p.RedirectExpirySec <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(p.RedirectExpirySec) * time.Second
+}
+
+// ListCacheTTL returns how long the version lists and latest versions
+// of upstream are cached, or 0 if they are not cached. It defaults to
+// 1 minute, and a negative ListCacheTTLSec turns the cache off.
+func (p *ProxyConfig) ListCacheTTL() time.Duration {
+	switch {
+	case p.ListCacheTTLSec < 0:
+		return 0
+	case p.ListCacheTTLSec == 0:
+		return time.Minute
+	}
+	return time.Duration(p.ListCacheTTLSec) * time.Second
+}
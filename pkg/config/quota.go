@@ -0,0 +1,12 @@
+package config
+
+// QuotaConfig specifies the space the disk and memory storage backends may use
+type QuotaConfig struct {
+	MaxSizeMB int64    `validate:"required" envconfig:"ATHENS_QUOTA_MAX_SIZE_MB"`
+	Pinned    []string `envconfig:"ATHENS_QUOTA_PINNED"`
+}
+
+// MaxBytes returns the quota in bytes
+func (q *QuotaConfig) MaxBytes() int64 {
+	return q.MaxSizeMB * 1024 * 1024
+}
@@ -0,0 +1,9 @@
+package config
+
+// ReplicatedConfig specifies the storage backends that
+// the replicated storage backend keeps copies of each module version in
+type ReplicatedConfig struct {
+	Backends          []string `validate:"required,min=1" envconfig:"ATHENS_REPLICATED_BACKENDS"`
+	WriteQuorum       int      `envconfig:"ATHENS_REPLICATED_WRITE_QUORUM"`
+	RepairIntervalSec int      `envconfig:"ATHENS_REPLICATED_REPAIR_INTERVAL_SEC"`
+}
@@ -4,17 +4,27 @@ import validator "gopkg.in/go-playground/validator.v9"
 
 // StorageConfig provides configs for various storage backends
 type StorageConfig struct {
-	CDN   *CDNConfig
-	Disk  *DiskConfig
-	GCP   *GCPConfig
-	Minio *MinioConfig
-	Mongo *MongoConfig
+	AzureBlob  *AzureBlobConfig
+	CDN        *CDNConfig
+	Disk       *DiskConfig
+	DiskCache  *DiskCacheConfig
+	Encryption *EncryptionConfig
+	GCP        *GCPConfig
+	Minio      *MinioConfig
+	Mongo      *MongoConfig
+	Quota      *QuotaConfig
+	Replicated *ReplicatedConfig
+	S3         *S3Config
+	WebDAV     *WebDAVConfig
 }
 
 func setStorageTimeouts(s *StorageConfig, defaultTimeout int) {
 	if s == nil {
 		return
 	}
+	if s.AzureBlob != nil && s.AzureBlob.Timeout == 0 {
+		s.AzureBlob.Timeout = defaultTimeout
+	}
 	if s.CDN != nil && s.CDN.Timeout == 0 {
 		s.CDN.Timeout = defaultTimeout
 	}
@@ -27,6 +37,12 @@ func setStorageTimeouts(s *StorageConfig, defaultTimeout int) {
 	if s.Mongo != nil && s.Mongo.Timeout == 0 {
 		s.Mongo.Timeout = defaultTimeout
 	}
+	if s.S3 != nil && s.S3.Timeout == 0 {
+		s.S3.Timeout = defaultTimeout
+	}
+	if s.WebDAV != nil && s.WebDAV.Timeout == 0 {
+		s.WebDAV.Timeout = defaultTimeout
+	}
 }
 
 // envconfig initializes *all* struct pointers, even if there are no corresponding defaults or env variables
@@ -34,6 +50,12 @@ func setStorageTimeouts(s *StorageConfig, defaultTimeout int) {
 func deleteInvalidStorageConfigs(s *StorageConfig) {
 	validate := validator.New()
 
+	if s.AzureBlob != nil {
+		if err := validate.Struct(s.AzureBlob); err != nil {
+			s.AzureBlob = nil
+		}
+	}
+
 	if s.CDN != nil {
 		if err := validate.Struct(s.CDN); err != nil {
 			s.CDN = nil
@@ -46,6 +68,18 @@ func deleteInvalidStorageConfigs(s *StorageConfig) {
 		}
 	}
 
+	if s.DiskCache != nil {
+		if err := validate.Struct(s.DiskCache); err != nil {
+			s.DiskCache = nil
+		}
+	}
+
+	if s.Encryption != nil {
+		if err := validate.Struct(s.Encryption); err != nil {
+			s.Encryption = nil
+		}
+	}
+
 	if s.GCP != nil {
 		if err := validate.Struct(s.GCP); err != nil {
 			s.GCP = nil
@@ -63,4 +97,28 @@ func deleteInvalidStorageConfigs(s *StorageConfig) {
 			s.Mongo = nil
 		}
 	}
+
+	if s.Quota != nil {
+		if err := validate.Struct(s.Quota); err != nil {
+			s.Quota = nil
+		}
+	}
+
+	if s.Replicated != nil {
+		if err := validate.Struct(s.Replicated); err != nil {
+			s.Replicated = nil
+		}
+	}
+
+	if s.S3 != nil {
+		if err := validate.Struct(s.S3); err != nil {
+			s.S3 = nil
+		}
+	}
+
+	if s.WebDAV != nil {
+		if err := validate.Struct(s.WebDAV); err != nil {
+			s.WebDAV = nil
+		}
+	}
 }
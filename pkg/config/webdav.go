@@ -0,0 +1,10 @@
+package config
+
+// WebDAVConfig specifies the properties required to use a WebDAV server as the storage backend
+type WebDAVConfig struct {
+	TimeoutConf
+	URL      string            `validate:"required" envconfig:"ATHENS_WEBDAV_URL"`
+	Username string            `envconfig:"ATHENS_WEBDAV_USERNAME"`
+	Password string            `envconfig:"ATHENS_WEBDAV_PASSWORD"`
+	Headers  map[string]string `envconfig:"ATHENS_WEBDAV_HEADERS"`
+}
@@ -127,3 +127,35 @@ func (p *withpool) Zip(ctx context.Context, mod, ver string) (io.ReadCloser, err
 	}
 	return zip, nil
 }
+
+func (p *withpool) Stat(ctx context.Context, mod, ver string) (storage.Metadata, error) {
+	const op errors.Op = "pool.Stat"
+	var meta storage.Metadata
+	var err error
+	done := make(chan struct{}, 1)
+	p.jobCh <- func() {
+		meta, err = p.dp.Stat(ctx, mod, ver)
+		close(done)
+	}
+	<-done
+	if err != nil {
+		return meta, errors.E(op, err)
+	}
+	return meta, nil
+}
+
+func (p *withpool) Redirect(ctx context.Context, mod, ver, ext string) (string, error) {
+	const op errors.Op = "pool.Redirect"
+	var u string
+	var err error
+	done := make(chan struct{}, 1)
+	p.jobCh <- func() {
+		u, err = p.dp.Redirect(ctx, mod, ver, ext)
+		close(done)
+	}
+	<-done
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return u, nil
+}
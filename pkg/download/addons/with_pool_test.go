@@ -85,6 +85,14 @@ func TestPoolWrapper(t *testing.T) {
 	if m.err.Error() != err.Error() {
 		t.Fatalf("dp.Zip: expected err to be `%v` but got `%v`", m.err, err)
 	}
+	_, err = dp.Stat(ctx, mod, ver)
+	if m.err.Error() != err.Error() {
+		t.Fatalf("dp.Stat: expected err to be `%v` but got `%v`", m.err, err)
+	}
+	_, err = dp.Redirect(ctx, mod, ver, "zip")
+	if m.err.Error() != err.Error() {
+		t.Fatalf("dp.Redirect: expected err to be `%v` but got `%v`", m.err, err)
+	}
 }
 
 type mockDP struct {
@@ -94,6 +102,7 @@ type mockDP struct {
 	latest   *storage.RevInfo
 	gomod    []byte
 	zip      io.ReadCloser
+	meta     storage.Metadata
 	inputMod string
 	inputVer string
 }
@@ -147,6 +156,28 @@ func (m *mockDP) Zip(ctx context.Context, mod, ver string) (io.ReadCloser, error
 	return m.zip, m.err
 }
 
+// Stat implements GET /{module}/@v/{version}.stat
+func (m *mockDP) Stat(ctx context.Context, mod, ver string) (storage.Metadata, error) {
+	if m.inputMod != mod {
+		return m.meta, fmt.Errorf("expected mod input %v but got %v", m.inputMod, mod)
+	}
+	if m.inputVer != ver {
+		return m.meta, fmt.Errorf("expected ver input %v but got %v", m.inputVer, ver)
+	}
+	return m.meta, m.err
+}
+
+// Redirect returns the URL of a file of a version
+func (m *mockDP) Redirect(ctx context.Context, mod, ver, ext string) (string, error) {
+	if m.inputMod != mod {
+		return "", fmt.Errorf("expected mod input %v but got %v", m.inputMod, mod)
+	}
+	if m.inputVer != ver {
+		return "", fmt.Errorf("expected ver input %v but got %v", m.inputVer, ver)
+	}
+	return "", m.err
+}
+
 // Version is a helper method to get Info, GoMod, and Zip together.
 func (m *mockDP) Version(ctx context.Context, mod, ver string) (*storage.Version, error) {
 	panic("skipped")
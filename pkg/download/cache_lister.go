@@ -0,0 +1,126 @@
+package download
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// maxCachedLists is the number of modules a cacheLister keeps the
+// answers of. Once it is reached, the least recently used one is evicted.
+const maxCachedLists = 10000
+
+type cachedList struct {
+	mod      string
+	rev      *storage.RevInfo
+	versions []string
+	err      error
+	expires  time.Time
+}
+
+type listCall struct {
+	done     chan struct{}
+	rev      *storage.RevInfo
+	versions []string
+	err      error
+}
+
+type cacheLister struct {
+	l   UpstreamLister
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	max      int
+	lru      *list.List // of *cachedList, most recently used first
+	cache    map[string]*list.Element
+	inFlight map[string]*listCall
+}
+
+// NewCacheLister wraps l in an UpstreamLister that remembers the answers of l
+// for ttl, including that a module was not found, and asks l only once for
+// concurrent lookups of the same module. When l fails after an answer expired,
+// the expired answer is served instead of the error.
+func NewCacheLister(l UpstreamLister, ttl time.Duration) UpstreamLister {
+	return &cacheLister{
+		l:        l,
+		ttl:      ttl,
+		now:      time.Now,
+		max:      maxCachedLists,
+		lru:      list.New(),
+		cache:    map[string]*list.Element{},
+		inFlight: map[string]*listCall{},
+	}
+}
+
+func (c *cacheLister) List(mod string) (*storage.RevInfo, []string, error) {
+	const op errors.Op = "cacheLister.List"
+	c.mu.Lock()
+	if el, ok := c.cache[mod]; ok && c.now().Before(el.Value.(*cachedList).expires) {
+		c.lru.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cachedList).answer(op)
+	}
+	call, ok := c.inFlight[mod]
+	if !ok {
+		call = &listCall{done: make(chan struct{})}
+		c.inFlight[mod] = call
+		go c.list(mod, call)
+	}
+	c.mu.Unlock()
+
+	<-call.done
+	if call.err != nil {
+		return nil, nil, errors.E(op, call.err)
+	}
+	return call.rev, call.versions, nil
+}
+
+// list asks the wrapped lister for the versions of mod
+// and hands the answer to everyone waiting on call
+func (c *cacheLister) list(mod string, call *listCall) {
+	rev, versions, err := c.l.List(mod)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, hasStale := c.cache[mod]
+	switch {
+	case err == nil || errors.IsRepoNotFoundErr(err):
+		c.add(&cachedList{mod: mod, rev: rev, versions: versions, err: err, expires: c.now().Add(c.ttl)})
+	case hasStale:
+		// upstream is unavailable, the last answer is better than none
+		c.lru.MoveToFront(el)
+		stale := el.Value.(*cachedList)
+		rev, versions, err = stale.rev, stale.versions, stale.err
+	}
+	call.rev, call.versions, call.err = rev, versions, err
+	delete(c.inFlight, mod)
+	close(call.done)
+}
+
+// add caches e, replacing an earlier answer for its module, and
+// evicts the least recently used answers once there are more than
+// c.max. It must be called with c.mu held.
+func (c *cacheLister) add(e *cachedList) {
+	if el, ok := c.cache[e.mod]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.cache[e.mod] = c.lru.PushFront(e)
+	for c.lru.Len() > c.max {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.cache, oldest.Value.(*cachedList).mod)
+	}
+}
+
+func (e *cachedList) answer(op errors.Op) (*storage.RevInfo, []string, error) {
+	if e.err != nil {
+		return nil, nil, errors.E(op, e.err)
+	}
+	return e.rev, e.versions, nil
+}
@@ -0,0 +1,192 @@
+package download
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheMod = "github.com/gomods/athens"
+
+// blockingLister counts its calls and answers
+// them only once release is closed
+type blockingLister struct {
+	release chan struct{}
+
+	mu       sync.Mutex
+	calls    int
+	versions []string
+	err      error
+}
+
+func (l *blockingLister) List(mod string) (*storage.RevInfo, []string, error) {
+	l.mu.Lock()
+	l.calls++
+	versions, err := l.versions, l.err
+	l.mu.Unlock()
+	if l.release != nil {
+		<-l.release
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &storage.RevInfo{Version: versions[len(versions)-1]}, versions, nil
+}
+
+func (l *blockingLister) set(versions []string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.versions, l.err = versions, err
+}
+
+func (l *blockingLister) callCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.calls
+}
+
+// newTestCacheLister returns a cacheLister around l
+// and a function that moves its clock forward
+func newTestCacheLister(l UpstreamLister, ttl time.Duration) (UpstreamLister, func(time.Duration)) {
+	now := time.Date(2018, 8, 1, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	c := NewCacheLister(l, ttl).(*cacheLister)
+	c.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	return c, func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+}
+
+func TestCacheListerTTL(t *testing.T) {
+	l := &blockingLister{versions: []string{"v1.0.0"}}
+	c, advance := newTestCacheLister(l, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		rev, versions, err := c.List(cacheMod)
+		require.NoError(t, err)
+		require.Equal(t, "v1.0.0", rev.Version)
+		require.Equal(t, []string{"v1.0.0"}, versions)
+	}
+	require.Equal(t, 1, l.callCount())
+
+	l.set([]string{"v1.0.0", "v1.1.0"}, nil)
+	advance(59 * time.Second)
+	_, versions, err := c.List(cacheMod)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0"}, versions)
+	require.Equal(t, 1, l.callCount())
+
+	advance(time.Second)
+	rev, versions, err := c.List(cacheMod)
+	require.NoError(t, err)
+	require.Equal(t, "v1.1.0", rev.Version)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0"}, versions)
+	require.Equal(t, 2, l.callCount())
+}
+
+func TestCacheListerNotFound(t *testing.T) {
+	l := &blockingLister{err: errors.E("lister", errors.KindNotFound)}
+	c, advance := newTestCacheLister(l, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := c.List(cacheMod)
+		require.Equal(t, errors.KindNotFound, errors.Kind(err))
+		require.True(t, errors.IsRepoNotFoundErr(err))
+	}
+	require.Equal(t, 1, l.callCount())
+
+	l.set([]string{"v1.0.0"}, nil)
+	advance(time.Minute)
+	_, versions, err := c.List(cacheMod)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0"}, versions)
+	require.Equal(t, 2, l.callCount())
+}
+
+func TestCacheListerServesStale(t *testing.T) {
+	l := &blockingLister{versions: []string{"v1.0.0"}}
+	c, advance := newTestCacheLister(l, time.Minute)
+
+	_, _, err := c.List(cacheMod)
+	require.NoError(t, err)
+
+	l.set(nil, fmt.Errorf("403 API rate limit exceeded"))
+	advance(time.Hour)
+	rev, versions, err := c.List(cacheMod)
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.0", rev.Version)
+	require.Equal(t, []string{"v1.0.0"}, versions)
+
+	// the failure is not cached, upstream is asked again
+	_, _, err = c.List(cacheMod)
+	require.NoError(t, err)
+	require.Equal(t, 3, l.callCount())
+
+	// without an earlier answer the error is returned
+	_, _, err = c.List("github.com/gomods/other")
+	require.Error(t, err)
+	require.False(t, errors.IsRepoNotFoundErr(err))
+}
+
+func TestCacheListerCoalesces(t *testing.T) {
+	l := &blockingLister{versions: []string{"v1.0.0"}, release: make(chan struct{})}
+	c := NewCacheLister(l, time.Minute)
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.List(cacheMod)
+			errs <- err
+		}()
+	}
+	// wait until the first lookup reached upstream before letting it answer
+	for l.callCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(l.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, l.callCount())
+}
+
+func TestCacheListerEvicts(t *testing.T) {
+	l := &blockingLister{versions: []string{"v1.0.0"}}
+	c, _ := newTestCacheLister(l, time.Minute)
+	c.(*cacheLister).max = 2
+
+	list := func(mod string) {
+		_, _, err := c.List(mod)
+		require.NoError(t, err)
+	}
+	list("github.com/gomods/a")
+	list("github.com/gomods/b")
+	list("github.com/gomods/a")
+	// b is the least recently used module and makes room for c
+	list("github.com/gomods/c")
+	require.Equal(t, 3, l.callCount())
+	list("github.com/gomods/a")
+	list("github.com/gomods/c")
+	require.Equal(t, 3, l.callCount())
+	list("github.com/gomods/b")
+	require.Equal(t, 4, l.callCount())
+	require.Len(t, c.(*cacheLister).cache, 2)
+}
@@ -0,0 +1,33 @@
+package download
+
+import (
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+type chainLister struct {
+	listers []UpstreamLister
+}
+
+// NewChainLister creates an UpstreamLister which tries listers in order,
+// with the same rules the go command uses for the GOPROXY list: a lister
+// that does not know the module falls through to the next one, but any
+// other error stops the chain. The first lister that knows the module is used.
+func NewChainLister(listers ...UpstreamLister) UpstreamLister {
+	return &chainLister{listers: listers}
+}
+
+func (c *chainLister) List(mod string) (*storage.RevInfo, []string, error) {
+	const op errors.Op = "chainLister.List"
+	for _, l := range c.listers {
+		rev, versions, err := l.List(mod)
+		if err != nil && errors.IsRepoNotFoundErr(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.E(op, err)
+		}
+		return rev, versions, nil
+	}
+	return nil, nil, errors.E(op, errors.M(mod), errors.KindNotFound)
+}
@@ -0,0 +1,52 @@
+package download
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLister struct {
+	versions []string
+	err      error
+	calls    int
+}
+
+func (l *stubLister) List(mod string) (*storage.RevInfo, []string, error) {
+	l.calls++
+	if l.err != nil {
+		return nil, nil, l.err
+	}
+	return &storage.RevInfo{Version: l.versions[len(l.versions)-1]}, l.versions, nil
+}
+
+func TestChainListerFallsThrough(t *testing.T) {
+	internal := &stubLister{err: errors.E("internal", errors.KindNotFound)}
+	vcs := &stubLister{err: fmt.Errorf("exit status 1: remote: Repository not found")}
+	direct := &stubLister{versions: []string{"v1.0.0", "v1.1.0"}}
+
+	rev, versions, err := NewChainLister(internal, vcs, direct).List("github.com/gomods/athens")
+	require.NoError(t, err)
+	require.Equal(t, "v1.1.0", rev.Version)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0"}, versions)
+}
+
+func TestChainListerStopsOnError(t *testing.T) {
+	internal := &stubLister{err: errors.E("internal", "unavailable")}
+	public := &stubLister{versions: []string{"v1.0.0"}}
+
+	_, _, err := NewChainLister(internal, public).List("github.com/gomods/athens")
+	require.Error(t, err)
+	require.False(t, errors.IsRepoNotFoundErr(err))
+	require.Equal(t, 0, public.calls)
+}
+
+func TestChainListerNotFound(t *testing.T) {
+	internal := &stubLister{err: errors.E("internal", errors.KindNotFound)}
+
+	_, _, err := NewChainLister(internal).List("github.com/gomods/athens")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err))
+}
@@ -0,0 +1,35 @@
+package download
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/module"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+type gitLister struct {
+	l *module.GitLister
+}
+
+// NewGitLister creates an UpstreamLister which lists the versions
+// of modules from the tags of their git repositories, without the go
+// command. It pairs with module.NewGitFetcher and the same repo.
+func NewGitLister(gitBinaryName string, fs afero.Fs, repo module.RepoFunc) (UpstreamLister, error) {
+	const op errors.Op = "download.NewGitLister"
+	l, err := module.NewGitLister(gitBinaryName, fs, repo)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &gitLister{l: l}, nil
+}
+
+func (l *gitLister) List(mod string) (*storage.RevInfo, []string, error) {
+	const op errors.Op = "gitLister.List"
+	rev, versions, err := l.l.List(context.Background(), mod)
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	return rev, versions, nil
+}
@@ -18,6 +18,8 @@ type HandlerOpts struct {
 	Protocol Protocol
 	Logger   *log.Logger
 	Engine   *render.Engine
+	// SumDB, if set, serves the checksum databases it proxies
+	SumDB *SumDB
 }
 
 // LogEntryHandler constructs a log.Entry out of the given
@@ -47,6 +49,11 @@ func RegisterHandlers(app *buffalo.App, opts *HandlerOpts) {
 	}
 	noCacheMw := middleware.CacheControl("no-cache, no-store, must-revalidate")
 
+	if opts.SumDB != nil {
+		app.GET(PathSumDBSupported, SumDBSupportedHandler(opts.SumDB))
+		app.GET(PathSumDB, SumDBHandler(opts.SumDB, opts.Logger))
+	}
+
 	listHandler := LogEntryHandler(ListHandler, opts)
 	app.GET(PathList, noCacheMw(listHandler))
 
@@ -55,5 +62,8 @@ func RegisterHandlers(app *buffalo.App, opts *HandlerOpts) {
 
 	app.GET(PathVersionInfo, LogEntryHandler(VersionInfoHandler, opts))
 	app.GET(PathVersionModule, LogEntryHandler(VersionModuleHandler, opts))
-	app.GET(PathVersionZip, LogEntryHandler(VersionZipHandler, opts))
+	zipHandler := LogEntryHandler(VersionZipHandler, opts)
+	app.GET(PathVersionZip, zipHandler)
+	app.HEAD(PathVersionZip, zipHandler)
+	app.GET(PathVersionStat, LogEntryHandler(VersionStatHandler, opts))
 }
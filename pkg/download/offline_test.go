@@ -0,0 +1,50 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/stash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffline(t *testing.T) {
+	_, s, _ := getVerifyDP(t)
+	ctx := context.Background()
+	stored := []string{"v1.0.0", "v1.1.0", "v1.2.0-pre", "v1.1.1-0.20180801120000-0123456789ab"}
+	for i, ver := range stored {
+		info := fmt.Sprintf(`{"Version":%q,"Time":"2018-08-0%dT00:00:00Z"}`, ver, i+1)
+		zip := testZip(verifyMod, ver, []byte(ver))
+		require.NoError(t, s.Save(ctx, verifyMod, ver, testGoMod(verifyMod, ver), bytes.NewReader(zip), []byte(info)))
+	}
+
+	f := &countingFetcher{}
+	// upstream is unreachable
+	lister := &listerMock{err: fmt.Errorf("dial tcp: no route to host")}
+	dp := New(&Opts{Storage: s, Stasher: stash.New(f, s), Lister: lister, Offline: true})
+
+	versions, err := dp.List(ctx, verifyMod)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0", "v1.1.1-0.20180801120000-0123456789ab", "v1.2.0-pre"}, versions)
+	_, err = dp.List(ctx, "github.com/athens-artifacts/notcached")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "List: %v", err)
+
+	latest, err := dp.Latest(ctx, verifyMod)
+	require.NoError(t, err)
+	require.Equal(t, "v1.1.0", latest.Version)
+	require.Equal(t, time.Date(2018, 8, 2, 0, 0, 0, 0, time.UTC), latest.Time)
+	_, err = dp.Latest(ctx, "github.com/athens-artifacts/notcached")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Latest: %v", err)
+
+	_, err = dp.Info(ctx, verifyMod, "v2.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Info: %v", err)
+	_, err = dp.GoMod(ctx, verifyMod, "v2.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "GoMod: %v", err)
+	_, err = dp.Zip(ctx, verifyMod, "v2.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Zip: %v", err)
+	require.Equal(t, 0, f.fetches, "nothing is fetched")
+}
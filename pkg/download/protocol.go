@@ -2,10 +2,13 @@ package download
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
 	"github.com/gomods/athens/pkg/stash"
 	"github.com/gomods/athens/pkg/storage"
 )
@@ -27,6 +30,15 @@ type Protocol interface {
 
 	// Zip implements GET /{module}/@v/{version}.zip
 	Zip(ctx context.Context, mod, ver string) (io.ReadCloser, error)
+
+	// Stat implements GET /{module}/@v/{version}.stat,
+	// it returns the metadata of a stored version and never stashes it
+	Stat(ctx context.Context, mod, ver string) (storage.Metadata, error)
+
+	// Redirect returns a URL that downloads the .info, .mod or .zip
+	// file of a version, as given by ext, straight from the storage.
+	// It returns an empty URL if the proxy should serve the file.
+	Redirect(ctx context.Context, mod, ver, ext string) (string, error)
 }
 
 // Wrapper helps extend the main protocol's functionality with addons.
@@ -37,6 +49,27 @@ type Opts struct {
 	Storage storage.Backend
 	Stasher stash.Stasher
 	Lister  UpstreamLister
+	// ReadOnly serves only the module versions in Storage.
+	// Versions that are not stored are not found instead of
+	// stashed, lists contain the stored versions only and the
+	// latest version is the newest one in Storage.
+	ReadOnly bool
+	// Offline serves only the module versions in Storage, like ReadOnly,
+	// and never consults the Lister either. It is meant for proxies
+	// without network access.
+	Offline bool
+	// RedirectExpiry enables redirects to signed URLs of the zips in
+	// Storage that are valid for RedirectExpiry. Storages that do not
+	// implement storage.Signer are not redirected to. Redirected
+	// downloads can not be verified against the stored checksums, so
+	// versions with a checksum are served by the proxy instead unless
+	// RedirectUnverified is set.
+	RedirectExpiry time.Duration
+	// RedirectModInfo redirects the .mod and .info files as well
+	RedirectModInfo bool
+	// RedirectUnverified redirects versions that have a stored checksum
+	// as well, without verifying what the storage serves
+	RedirectUnverified bool
 }
 
 // New returns a full implementation of the download.Protocol
@@ -45,7 +78,21 @@ type Opts struct {
 // The wrappers are applied in order, meaning the last wrapper
 // passed is the Protocol that gets hit first.
 func New(opts *Opts, wrappers ...Wrapper) Protocol {
-	var p Protocol = &protocol{opts.Storage, opts.Stasher, opts.Lister}
+	var p Protocol = &protocol{
+		s:                  opts.Storage,
+		stasher:            opts.Stasher,
+		lister:             opts.Lister,
+		readOnly:           opts.ReadOnly,
+		offline:            opts.Offline,
+		redirectExpiry:     opts.RedirectExpiry,
+		redirectModInfo:    opts.RedirectModInfo,
+		redirectUnverified: opts.RedirectUnverified,
+		refetches: &refetches{
+			inFlight:    map[string]*refetchCall{},
+			quarantined: map[string]error{},
+			replaced:    map[string]time.Time{},
+		},
+	}
 	for _, w := range wrappers {
 		p = w(p)
 	}
@@ -54,9 +101,17 @@ func New(opts *Opts, wrappers ...Wrapper) Protocol {
 }
 
 type protocol struct {
-	s       storage.Backend
-	stasher stash.Stasher
-	lister  UpstreamLister
+	s        storage.Backend
+	stasher  stash.Stasher
+	lister   UpstreamLister
+	readOnly bool
+	offline  bool
+
+	redirectExpiry     time.Duration
+	redirectModInfo    bool
+	redirectUnverified bool
+
+	refetches *refetches
 }
 
 func (p *protocol) List(ctx context.Context, mod string) ([]string, error) {
@@ -70,6 +125,13 @@ func (p *protocol) List(ctx context.Context, mod string) ([]string, error) {
 	if sErr != nil {
 		return nil, errors.E(op, sErr)
 	}
+	if p.readOnly || p.offline {
+		if len(strList) == 0 {
+			return nil, errors.E(op, errors.M(mod), errors.KindNotFound)
+		}
+		semver.Sort(strList)
+		return strList, nil
+	}
 	_, goList, goErr := p.lister.List(mod)
 	isUnexpGoErr := goErr != nil && !errors.IsRepoNotFoundErr(goErr)
 	// if i.e. github is unavailable we should fail as well so that the behavior of the proxy is stable.
@@ -84,27 +146,66 @@ func (p *protocol) List(ctx context.Context, mod string) ([]string, error) {
 		return nil, errors.E(op, errors.M(mod), errors.KindNotFound, goErr)
 	}
 
-	return union(goList, strList), nil
+	list := union(goList, strList)
+	semver.Sort(list)
+	return list, nil
 }
 
 func (p *protocol) Latest(ctx context.Context, mod string) (*storage.RevInfo, error) {
 	const op errors.Op = "protocol.Latest"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	if p.readOnly || p.offline {
+		// the latest version upstream may not be stored
+		return p.latestStored(ctx, mod)
+	}
 	lr, _, err := p.lister.List(mod)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	if lr == nil {
+		return nil, errors.E(op, errors.M(mod), errors.KindNotFound)
+	}
 
 	return lr, nil
 }
 
+// latestStored returns the .info of the newest version of mod in storage
+func (p *protocol) latestStored(ctx context.Context, mod string) (*storage.RevInfo, error) {
+	const op errors.Op = "protocol.latestStored"
+	versions, err := p.s.List(ctx, mod)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	ver := semver.Latest(versions)
+	if ver == "" {
+		return nil, errors.E(op, errors.M(mod), errors.KindNotFound)
+	}
+	info, err := p.Info(ctx, mod, ver)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var rev storage.RevInfo
+	if err := json.Unmarshal(info, &rev); err != nil {
+		return nil, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	return &rev, nil
+}
+
+// stashes reports whether versions that are not in storage are stashed from upstream
+func (p *protocol) stashes() bool {
+	return !p.readOnly && !p.offline
+}
+
 func (p *protocol) Info(ctx context.Context, mod, ver string) ([]byte, error) {
 	const op errors.Op = "protocol.Info"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	if err := p.waitRefetch(ctx, mod, ver); err != nil {
+		return nil, errors.E(op, err)
+	}
 	info, err := p.s.Info(ctx, mod, ver)
-	if errors.IsNotFoundErr(err) {
+	if errors.IsNotFoundErr(err) && p.stashes() {
 		err = p.stasher.Stash(ctx, mod, ver)
 		if err != nil {
 			return nil, errors.E(op, err)
@@ -122,8 +223,12 @@ func (p *protocol) GoMod(ctx context.Context, mod, ver string) ([]byte, error) {
 	const op errors.Op = "protocol.GoMod"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	if err := p.waitRefetch(ctx, mod, ver); err != nil {
+		return nil, errors.E(op, err)
+	}
+	readAt := time.Now()
 	goMod, err := p.s.GoMod(ctx, mod, ver)
-	if errors.IsNotFoundErr(err) {
+	if errors.IsNotFoundErr(err) && p.stashes() {
 		err = p.stasher.Stash(ctx, mod, ver)
 		if err != nil {
 			return nil, errors.E(op, err)
@@ -133,6 +238,19 @@ func (p *protocol) GoMod(ctx context.Context, mod, ver string) ([]byte, error) {
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	err = p.verifyGoMod(ctx, mod, ver, goMod)
+	if errors.Kind(err) == errors.KindChecksumMismatch {
+		// the stored copy is corrupt, replace it and try once more
+		if err = p.refetch(ctx, mod, ver, readAt); err == nil {
+			goMod, err = p.s.GoMod(ctx, mod, ver)
+			if err == nil {
+				err = p.verifyGoMod(ctx, mod, ver, goMod)
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
 
 	return goMod, nil
 }
@@ -141,8 +259,12 @@ func (p *protocol) Zip(ctx context.Context, mod, ver string) (io.ReadCloser, err
 	const op errors.Op = "protocol.Zip"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	if err := p.waitRefetch(ctx, mod, ver); err != nil {
+		return nil, errors.E(op, err)
+	}
+	readAt := time.Now()
 	zip, err := p.s.Zip(ctx, mod, ver)
-	if errors.IsNotFoundErr(err) {
+	if errors.IsNotFoundErr(err) && p.stashes() {
 		err = p.stasher.Stash(ctx, mod, ver)
 		if err != nil {
 			return nil, errors.E(op, err)
@@ -152,25 +274,48 @@ func (p *protocol) Zip(ctx context.Context, mod, ver string) (io.ReadCloser, err
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	zip, err = p.verifyZip(ctx, mod, ver, zip, readAt)
+	if errors.Kind(err) == errors.KindChecksumMismatch {
+		// the stored copy is corrupt, replace it and try once more
+		if err = p.refetch(ctx, mod, ver, readAt); err == nil {
+			readAt = time.Now()
+			zip, err = p.s.Zip(ctx, mod, ver)
+			if err == nil {
+				zip, err = p.verifyZip(ctx, mod, ver, zip, readAt)
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
 
 	return zip, nil
 }
 
+func (p *protocol) Stat(ctx context.Context, mod, ver string) (storage.Metadata, error) {
+	const op errors.Op = "protocol.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	meta, err := p.s.Stat(ctx, mod, ver)
+	if err != nil {
+		return meta, errors.E(op, err)
+	}
+
+	return meta, nil
+}
+
 // union concatenates two version lists and removes duplicates
 func union(list1, list2 []string) []string {
-	if list1 == nil {
-		list1 = []string{}
-	}
-	if list2 == nil {
-		list2 = []string{}
-	}
-	list := append(list1, list2...)
+	// list1 may be shared with other requests by a cached lister,
+	// so the union is built in a new slice instead of appending to it
 	unique := []string{}
 	m := make(map[string]struct{})
-	for _, v := range list {
-		if _, ok := m[v]; !ok {
-			unique = append(unique, v)
-			m[v] = struct{}{}
+	for _, list := range [][]string{list1, list2} {
+		for _, v := range list {
+			if _, ok := m[v]; !ok {
+				unique = append(unique, v)
+				m[v] = struct{}{}
+			}
 		}
 	}
 	return unique
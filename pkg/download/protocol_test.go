@@ -1,6 +1,7 @@
 package download
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -42,7 +43,7 @@ func getDP(t *testing.T) Protocol {
 		t.Fatal(err)
 	}
 	st := stash.New(mf, s)
-	return New(&Opts{s, st, NewVCSLister(goBin, fs)})
+	return New(&Opts{Storage: s, Stasher: st, Lister: NewVCSLister(goBin, fs)})
 }
 
 type listTest struct {
@@ -272,7 +273,7 @@ func TestDownloadProtocol(t *testing.T) {
 	}
 	mp := &mockFetcher{}
 	st := stash.New(mp, s)
-	dp := New(&Opts{s, st, nil})
+	dp := New(&Opts{Storage: s, Stasher: st})
 	ctx := context.Background()
 
 	var eg errgroup.Group
@@ -294,7 +295,7 @@ func TestDownloadProtocol(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if !bytes.Equal(bts, []byte(m.mod+"@"+m.ver)) {
+		if !bytes.Equal(bts, testGoMod(m.mod, m.ver)) {
 			t.Fatalf("unexpected gomod content: %s", bts)
 		}
 	}
@@ -305,8 +306,23 @@ type mockFetcher struct{}
 func (m *mockFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
 	bts := []byte(mod + "@" + ver)
 	return &storage.Version{
-		Mod:  bts,
+		Mod:  testGoMod(mod, ver),
 		Info: bts,
-		Zip:  ioutil.NopCloser(bytes.NewReader(bts)),
+		Zip:  ioutil.NopCloser(bytes.NewReader(testZip(mod, ver, bts))),
 	}, nil
 }
+
+// testGoMod returns the go.mod file of mod@ver that mockFetcher serves
+func testGoMod(mod, ver string) []byte {
+	return []byte("module " + mod + " // " + ver + "\n")
+}
+
+// testZip returns a module zip of mod@ver holding a single file with content
+func testZip(mod, ver string, content []byte) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, _ := zw.Create(mod + "@" + ver + "/mod.go")
+	w.Write(content)
+	zw.Close()
+	return buf.Bytes()
+}
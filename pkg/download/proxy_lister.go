@@ -0,0 +1,60 @@
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/module"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+type proxyLister struct {
+	url     string
+	timeout time.Duration
+}
+
+// NewProxyLister creates an UpstreamLister which asks the GOPROXY
+// compatible servers at urls, in order, for the available versions.
+// The first server that knows the module is used.
+func NewProxyLister(timeout time.Duration, urls ...string) UpstreamLister {
+	listers := make([]UpstreamLister, 0, len(urls))
+	for _, u := range urls {
+		listers = append(listers, &proxyLister{url: u, timeout: timeout})
+	}
+	if len(listers) == 1 {
+		return listers[0]
+	}
+	return NewChainLister(listers...)
+}
+
+func (l *proxyLister) List(mod string) (*storage.RevInfo, []string, error) {
+	const op errors.Op = "proxyLister.List"
+	ctx := context.Background()
+	list, err := module.DownloadFile(ctx, l.timeout, l.url, mod, "@v/list")
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+
+	versions := []string{}
+	for _, v := range strings.Split(string(list), "\n") {
+		if v = strings.TrimSpace(v); v != "" {
+			versions = append(versions, v)
+		}
+	}
+
+	latest, err := module.DownloadFile(ctx, l.timeout, l.url, mod, "@latest")
+	if errors.IsNotFoundErr(err) {
+		return nil, versions, nil
+	}
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	var rev storage.RevInfo
+	if err := json.Unmarshal(latest, &rev); err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	return &rev, versions, nil
+}
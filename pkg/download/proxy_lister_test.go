@@ -0,0 +1,61 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func listProxy(list, latest string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/github.com/!azure/go-autorest/@v/list":
+			w.Write([]byte(list))
+		case "/github.com/!azure/go-autorest/@latest":
+			if latest == "" {
+				http.Error(w, "gone", http.StatusGone)
+				return
+			}
+			w.Write([]byte(latest))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestProxyLister(t *testing.T) {
+	empty := httptest.NewServer(http.NotFoundHandler())
+	defer empty.Close()
+	full := listProxy("v1.0.0\nv1.1.0\n", `{"Version":"v1.1.0","Time":"2018-08-01T00:00:00Z"}`)
+	defer full.Close()
+
+	l := NewProxyLister(time.Minute, empty.URL, full.URL)
+	rev, versions, err := l.List("github.com/Azure/go-autorest")
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0"}, versions)
+	require.Equal(t, "v1.1.0", rev.Version)
+	require.Equal(t, time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC), rev.Time)
+}
+
+func TestProxyListerNoLatest(t *testing.T) {
+	full := listProxy("", "")
+	defer full.Close()
+
+	rev, versions, err := NewProxyLister(time.Minute, full.URL).List("github.com/Azure/go-autorest")
+	require.NoError(t, err)
+	require.Nil(t, rev)
+	require.Empty(t, versions)
+}
+
+func TestProxyListerNotFound(t *testing.T) {
+	empty := httptest.NewServer(http.NotFoundHandler())
+	defer empty.Close()
+
+	_, _, err := NewProxyLister(time.Minute, empty.URL).List("github.com/Azure/go-autorest")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err))
+	require.True(t, errors.IsRepoNotFoundErr(err))
+}
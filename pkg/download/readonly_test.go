@@ -0,0 +1,52 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/stash"
+	"github.com/gomods/athens/pkg/storage/readonly"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly(t *testing.T) {
+	dp, s, _ := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	newer := []byte(`{"Version":"v1.1.0","Time":"2018-08-01T00:00:00Z"}`)
+	zip := testZip(verifyMod, "v1.1.0", newer)
+	require.NoError(t, s.Save(ctx, verifyMod, "v1.1.0", testGoMod(verifyMod, "v1.1.0"), bytes.NewReader(zip), newer))
+
+	f := &countingFetcher{}
+	ro := readonly.New(s)
+	dp = New(&Opts{Storage: ro, Stasher: stash.New(f, ro), Lister: &listerMock{versions: []string{"v2.0.0"}}, ReadOnly: true})
+
+	info, err := dp.Info(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.NotEmpty(t, info)
+	_, err = dp.Info(ctx, verifyMod, "v2.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Info: %v", err)
+	_, err = dp.Zip(ctx, verifyMod, "v2.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Zip: %v", err)
+	require.Equal(t, 0, f.fetches, "nothing is stashed")
+
+	versions, err := dp.List(ctx, verifyMod)
+	require.NoError(t, err)
+	require.Equal(t, []string{verifyVer, "v1.1.0"}, versions)
+	_, err = dp.List(ctx, "github.com/athens-artifacts/notcached")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "List: %v", err)
+	rev, err := dp.Latest(ctx, verifyMod)
+	require.NoError(t, err)
+	require.Equal(t, "v1.1.0", rev.Version, "the newest stored version, not the one upstream")
+	_, err = dp.Latest(ctx, "github.com/athens-artifacts/notcached")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Latest: %v", err)
+
+	// a corrupt version is reported, not replaced
+	corrupt(t, s)
+	_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "GoMod: %v", err)
+	require.Equal(t, 0, f.fetches)
+}
@@ -0,0 +1,69 @@
+package download
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+func (p *protocol) Redirect(ctx context.Context, mod, ver, ext string) (string, error) {
+	const op errors.Op = "protocol.Redirect"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if p.redirectExpiry <= 0 || (ext != "zip" && !p.redirectModInfo) {
+		return "", nil
+	}
+	u, err := storage.SignedURL(ctx, p.s, mod, ver, ext, p.redirectExpiry)
+	if errors.IsNotFoundErr(err) && p.stashes() {
+		if err = p.stasher.Stash(ctx, mod, ver); err != nil {
+			return "", errors.E(op, err)
+		}
+		u, err = storage.SignedURL(ctx, p.s, mod, ver, ext, p.redirectExpiry)
+	}
+	if errors.Kind(err) == errors.KindNotImplemented {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	if ext != "info" && !p.redirectUnverified {
+		// the proxy verifies the .mod and .zip files it serves
+		_, err := p.s.Checksum(ctx, mod, ver)
+		if err == nil {
+			return "", nil
+		}
+		if !errors.IsNotFoundErr(err) {
+			return "", errors.E(op, err)
+		}
+	}
+	return u, nil
+}
+
+// redirect answers c with a redirect to the ext file of mod@ver if
+// dp redirects it and reports whether it did. Versions that can not
+// be found are answered with a 404. On other errors, c is left for
+// the caller to serve, so that downloads keep working through the proxy.
+func redirect(c buffalo.Context, dp Protocol, lggr log.Entry, mod, ver, ext string) bool {
+	const op errors.Op = "download.redirect"
+	u, err := dp.Redirect(c, mod, ver, ext)
+	if errors.IsNotFoundErr(err) {
+		lggr.SystemErr(errors.E(op, err, errors.M(mod), errors.V(ver)))
+		c.Render(errors.Kind(err), nil)
+		return true
+	}
+	if err != nil {
+		lggr.SystemErr(errors.E(op, err, errors.M(mod), errors.V(ver)))
+		return false
+	}
+	if u == "" {
+		return false
+	}
+	// buffalo's c.Redirect would set a session cookie
+	http.Redirect(c.Response(), c.Request(), u, http.StatusFound)
+	return true
+}
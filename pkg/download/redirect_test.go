@@ -0,0 +1,114 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/stash"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+// signingStorage signs URLs of a made up CDN
+type signingStorage struct {
+	storage.Backend
+}
+
+func (s *signingStorage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.E("signingStorage.SignedURL", errors.KindNotFound)
+	}
+	return "https://cdn.example.com/" + module + "/@v/" + version + "." + ext, nil
+}
+
+func redirectApp(t *testing.T, opts *Opts) (*buffalo.App, *countingFetcher) {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	f := &countingFetcher{}
+	opts.Storage = &signingStorage{s}
+	opts.Stasher = stash.New(f, s)
+	app := buffalo.New(buffalo.Options{})
+	RegisterHandlers(app, &HandlerOpts{
+		Protocol: New(opts),
+		Logger:   log.New("none", logrus.PanicLevel),
+		Engine:   render.New(render.Options{}),
+	})
+	return app, f
+}
+
+func getFile(t *testing.T, app *buffalo.App, ext string) (int, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("GET", "/"+verifyMod+"/@v/"+verifyVer+"."+ext, nil)
+	require.NoError(t, err)
+	app.ServeHTTP(w, r)
+	return w.Code, w.Header().Get("Location")
+}
+
+func TestRedirect(t *testing.T) {
+	app, f := redirectApp(t, &Opts{RedirectExpiry: time.Minute, RedirectUnverified: true})
+	code, loc := getFile(t, app, "zip")
+	require.Equal(t, http.StatusFound, code)
+	require.Equal(t, "https://cdn.example.com/"+verifyMod+"/@v/"+verifyVer+".zip", loc)
+	require.Equal(t, 1, f.fetches)
+
+	// only zips are redirected by default
+	code, loc = getFile(t, app, "mod")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, loc)
+}
+
+func TestRedirectModInfo(t *testing.T) {
+	app, _ := redirectApp(t, &Opts{RedirectExpiry: time.Minute, RedirectModInfo: true, RedirectUnverified: true})
+	for _, ext := range []string{"info", "mod", "zip"} {
+		code, loc := getFile(t, app, ext)
+		require.Equal(t, http.StatusFound, code, ext)
+		require.Equal(t, "https://cdn.example.com/"+verifyMod+"/@v/"+verifyVer+"."+ext, loc)
+	}
+}
+
+func TestRedirectVerified(t *testing.T) {
+	app, f := redirectApp(t, &Opts{RedirectExpiry: time.Minute, RedirectModInfo: true})
+	// versions with a checksum are served and verified by the proxy
+	for _, ext := range []string{"mod", "zip"} {
+		code, loc := getFile(t, app, ext)
+		require.Equal(t, http.StatusOK, code, ext)
+		require.Empty(t, loc, ext)
+	}
+	require.Equal(t, 1, f.fetches)
+	// the .info file is not verified either way
+	code, loc := getFile(t, app, "info")
+	require.Equal(t, http.StatusFound, code)
+	require.Equal(t, "https://cdn.example.com/"+verifyMod+"/@v/"+verifyVer+".info", loc)
+}
+
+func TestRedirectOff(t *testing.T) {
+	app, _ := redirectApp(t, &Opts{})
+	code, loc := getFile(t, app, "zip")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, loc)
+}
+
+func TestRedirectReadOnlyNotFound(t *testing.T) {
+	app, f := redirectApp(t, &Opts{RedirectExpiry: time.Minute, ReadOnly: true})
+	code, _ := getFile(t, app, "zip")
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, 0, f.fetches)
+}
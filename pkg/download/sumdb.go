@@ -0,0 +1,198 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/module"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// PathSumDBSupported URL.
+const PathSumDBSupported = "/sumdb/{sumdb}/supported"
+
+// PathSumDB URL.
+const PathSumDB = "/sumdb/{sumdb}/{path:(?:lookup|tile)/.+}"
+
+// maxSumDBResponse is the size limit of responses of checksum databases.
+// Tiles are at most 8 KiB and lookups a few hundred bytes.
+const maxSumDBResponse = 1 << 20
+
+var sumDBTileRE = regexp.MustCompile(`^tile/[0-9]+/([0-9]+|data)/(x[0-9]{3}/)*[0-9]{3}(\.p/[0-9]+)?$`)
+
+// SumDB proxies the checksum databases that the go command
+// verifies modules with, and keeps their records and tiles
+// in storage if the storage is a storage.SumDBCacher
+type SumDB struct {
+	urls    map[string]string
+	s       storage.Backend
+	timeout time.Duration
+}
+
+// NewSumDB returns a SumDB that proxies the checksum databases at urls,
+// such as "https://sum.golang.org", under the name the go command knows
+// them by, which is the host and path of the URL, such as "sum.golang.org".
+func NewSumDB(s storage.Backend, timeout time.Duration, urls ...string) (*SumDB, error) {
+	const op errors.Op = "download.NewSumDB"
+	db := &SumDB{urls: map[string]string{}, s: s, timeout: timeout}
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		if u.Host == "" {
+			return nil, errors.E(op, fmt.Sprintf("checksum database URL %q has no host", rawURL))
+		}
+		db.urls[strings.TrimSuffix(u.Host+u.Path, "/")] = strings.TrimSuffix(rawURL, "/")
+	}
+	return db, nil
+}
+
+// Supported reports whether the checksum database name is proxied
+func (db *SumDB) Supported(name string) bool {
+	_, ok := db.urls[name]
+	return ok
+}
+
+// Get returns the response of the checksum database name to p,
+// which is either a lookup of a module version or a tile. Responses
+// are read from storage, and saved to it once fetched from upstream.
+func (db *SumDB) Get(ctx context.Context, name, p string) ([]byte, error) {
+	const op errors.Op = "SumDB.Get"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	baseURL, ok := db.urls[name]
+	if !ok {
+		return nil, errors.E(op, fmt.Sprintf("checksum database %s is not proxied", name), errors.KindNotFound)
+	}
+	if err := checkSumDBPath(p); err != nil {
+		return nil, errors.E(op, err, errors.KindBadRequest)
+	}
+
+	key := name + "/" + p
+	content, err := storage.SumDB(ctx, db.s, key)
+	if err == nil {
+		return content, nil
+	}
+	if !errors.IsNotFoundErr(err) && errors.Kind(err) != errors.KindNotImplemented {
+		return nil, errors.E(op, err)
+	}
+
+	content, err = db.fetch(ctx, baseURL, p)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// records and tiles never change, a failed save only
+	// means that they are fetched again next time
+	storage.SaveSumDB(ctx, db.s, key, content)
+	return content, nil
+}
+
+// fetch gets p from the checksum database at baseURL
+func (db *SumDB) fetch(ctx context.Context, baseURL, p string) ([]byte, error) {
+	const op errors.Op = "SumDB.fetch"
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	defer cancel()
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// lookups are of escaped module paths,
+	// keep their exclamation marks unescaped
+	u.Path = path.Join(u.Path, p)
+	u.RawPath = u.Path
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		return nil, errors.E(op, fmt.Errorf("%s: %s", u, res.Status), errors.KindNotFound)
+	default:
+		return nil, errors.E(op, fmt.Errorf("%s: %s", u, res.Status))
+	}
+	content, err := ioutil.ReadAll(&io.LimitedReader{R: res.Body, N: maxSumDBResponse + 1})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if len(content) > maxSumDBResponse {
+		return nil, errors.E(op, fmt.Sprintf("%s: response is larger than %d bytes", u, maxSumDBResponse))
+	}
+	return content, nil
+}
+
+// checkSumDBPath checks that p is a tile or a lookup of
+// an escaped module version, which is also a valid file path
+func checkSumDBPath(p string) error {
+	if strings.HasPrefix(p, "tile/") {
+		if !sumDBTileRE.MatchString(p) {
+			return fmt.Errorf("invalid tile %q", p)
+		}
+		return nil
+	}
+	modVer := strings.TrimPrefix(p, "lookup/")
+	i := strings.LastIndex(modVer, "@")
+	if modVer == p || i <= 0 || i == len(modVer)-1 || strings.Contains(modVer[i:], "/") {
+		return fmt.Errorf("invalid lookup %q", p)
+	}
+	if err := module.CheckFilePath(modVer); err != nil {
+		return fmt.Errorf("invalid lookup %q: %v", p, err)
+	}
+	return nil
+}
+
+// SumDBSupportedHandler implements GET baseURL/sumdb/name/supported
+func SumDBSupportedHandler(db *SumDB) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		if !db.Supported(c.Param("sumdb")) {
+			return c.Render(http.StatusNotFound, nil)
+		}
+		return c.Render(http.StatusOK, nil)
+	}
+}
+
+// SumDBHandler implements GET baseURL/sumdb/name/lookup/module@version
+// and GET baseURL/sumdb/name/tile/...
+func SumDBHandler(db *SumDB, l *log.Logger) buffalo.Handler {
+	const op errors.Op = "download.SumDBHandler"
+	return func(c buffalo.Context) error {
+		req := c.Request()
+		lggr := l.WithFields(logrus.Fields{
+			"http-method": req.Method,
+			"http-path":   req.URL.Path,
+			"http-url":    req.URL.String(),
+		})
+		content, err := db.Get(c, c.Param("sumdb"), c.Param("path"))
+		if err != nil {
+			lggr.SystemErr(errors.E(op, err))
+			return c.Render(errors.Kind(err), nil)
+		}
+		c.Response().Header().Set("Content-Type", "application/octet-stream")
+		// Calling c.Response().Write will write the header directly
+		// and we would get a 0 status in the buffalo logs.
+		c.Render(http.StatusOK, nil)
+		if _, err := c.Response().Write(content); err != nil {
+			lggr.SystemErr(errors.E(op, err))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sumDBLookup = "lookup/github.com/!azure/azure-sdk-for-go@v1.0.0"
+	sumDBRecord = "12\ngithub.com/Azure/azure-sdk-for-go v1.0.0 h1:abc=\n\ngo.sum database tree\n13\n"
+	sumDBTile   = "tile/8/0/000"
+)
+
+// sumDBStandIn serves one lookup and one tile like a checksum
+// database and counts the requests for each path
+type sumDBStandIn struct {
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (db *sumDBStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db.mu.Lock()
+	db.requests[r.URL.EscapedPath()]++
+	db.mu.Unlock()
+	switch r.URL.EscapedPath() {
+	case "/" + sumDBLookup:
+		w.Write([]byte(sumDBRecord))
+	case "/" + sumDBTile:
+		w.Write([]byte(strings.Repeat("x", 8192)))
+	case "/tile/8/0/001":
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (db *sumDBStandIn) count(p string) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.requests["/"+p]
+}
+
+// sumDBApp returns an app proxying a sumdb stand-in, the name
+// the stand-in is known by, the stand-in and its server
+func sumDBApp(t *testing.T, s storage.Backend) (*buffalo.App, string, *sumDBStandIn, *httptest.Server) {
+	t.Helper()
+	standIn := &sumDBStandIn{requests: map[string]int{}}
+	srv := httptest.NewServer(standIn)
+	db, err := NewSumDB(s, time.Second, srv.URL)
+	require.NoError(t, err)
+
+	app := buffalo.New(buffalo.Options{})
+	RegisterHandlers(app, &HandlerOpts{
+		Protocol: New(&Opts{Storage: s}),
+		Logger:   log.New("none", logrus.PanicLevel),
+		Engine:   render.New(render.Options{}),
+		SumDB:    db,
+	})
+	return app, strings.TrimPrefix(srv.URL, "http://"), standIn, srv
+}
+
+func sumDBStorage(t *testing.T) storage.Backend {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return s
+}
+
+func getSumDB(t *testing.T, app *buffalo.App, p string) (int, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("GET", "/sumdb/"+p, nil)
+	require.NoError(t, err)
+	app.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+func TestSumDBSupported(t *testing.T) {
+	app, name, _, srv := sumDBApp(t, sumDBStorage(t))
+	defer srv.Close()
+	code, _ := getSumDB(t, app, name+"/supported")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = getSumDB(t, app, "sum.example.com/supported")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestSumDBCaches(t *testing.T) {
+	app, name, standIn, srv := sumDBApp(t, sumDBStorage(t))
+	defer srv.Close()
+	for i := 0; i < 2; i++ {
+		code, body := getSumDB(t, app, name+"/"+sumDBLookup)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, sumDBRecord, body)
+		code, body = getSumDB(t, app, name+"/"+sumDBTile)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, body, 8192)
+	}
+	require.Equal(t, 1, standIn.count(sumDBLookup))
+	require.Equal(t, 1, standIn.count(sumDBTile))
+}
+
+func TestSumDBPassThrough(t *testing.T) {
+	// a storage that can not cache checksum databases
+	app, name, standIn, srv := sumDBApp(t, struct{ storage.Backend }{sumDBStorage(t)})
+	defer srv.Close()
+	for i := 0; i < 2; i++ {
+		code, body := getSumDB(t, app, name+"/"+sumDBLookup)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, sumDBRecord, body)
+	}
+	require.Equal(t, 2, standIn.count(sumDBLookup))
+}
+
+func TestSumDBErrors(t *testing.T) {
+	app, name, standIn, srv := sumDBApp(t, sumDBStorage(t))
+	defer srv.Close()
+	for p, want := range map[string]int{
+		name + "/lookup/github.com/gomods/athens@v9.9.9": http.StatusNotFound,
+		name + "/tile/8/0/001":                           http.StatusInternalServerError,
+		name + "/tile/8/0/abc":                           http.StatusBadRequest,
+		name + "/lookup/github.com/gomods/athens":        http.StatusBadRequest,
+		name + "/lookup/github.com/go<mods>@v1.0.0":      http.StatusBadRequest,
+		"sum.example.com/" + sumDBLookup:                 http.StatusNotFound,
+	} {
+		code, _ := getSumDB(t, app, p)
+		require.Equal(t, want, code, p)
+	}
+	// failures are not cached
+	getSumDB(t, app, name+"/tile/8/0/001")
+	require.Equal(t, 2, standIn.count("tile/8/0/001"))
+}
@@ -0,0 +1,359 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/module"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// verifyGoMod checks goMod against the go.sum hash stored for mod@ver.
+// Versions stored without a checksum are not verified.
+func (p *protocol) verifyGoMod(ctx context.Context, mod, ver string, goMod []byte) error {
+	const op errors.Op = "protocol.verifyGoMod"
+	sum, err := p.s.Checksum(ctx, mod, ver)
+	if errors.IsNotFoundErr(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.E(op, err)
+	}
+	h, err := module.HashGoMod(goMod)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if h != sum.GoModSum {
+		err := fmt.Errorf("go.mod hash %s does not match the stored %s", h, sum.GoModSum)
+		return errors.E(op, err, errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	return nil
+}
+
+// verifyZip checks zip against the checksum stored for mod@ver.
+// Versions stored without a checksum are not verified and zip is
+// returned as is. zip is closed on errors.
+//
+// When the checksum has a ZipHash, zip is verified as it is read: the
+// last byte is held back until the SHA-256 of everything before it
+// matches, and a mismatch fails the read and refetches the version, so
+// that a corrupt zip is never served whole. Zips that are read from an
+// offset, as for Range requests, can not be verified.
+//
+// Checksums recorded before ZipHash existed only have the go.sum hash,
+// which needs the whole zip. Those zips are spooled to a temporary file,
+// verified and served from there, and the ZipHash is recorded so that
+// later reads stream.
+func (p *protocol) verifyZip(ctx context.Context, mod, ver string, zip io.ReadCloser, readAt time.Time) (io.ReadCloser, error) {
+	const op errors.Op = "protocol.verifyZip"
+	sum, err := p.s.Checksum(ctx, mod, ver)
+	if errors.IsNotFoundErr(err) {
+		return zip, nil
+	}
+	if err != nil {
+		zip.Close()
+		return nil, errors.E(op, err)
+	}
+	if sum.ZipHash != "" {
+		v := &verifyingZip{
+			zip:  zip,
+			h:    sha256.New(),
+			want: sum.ZipHash,
+			onMismatch: func() {
+				p.startRefetch(mod, ver, readAt)
+			},
+			verify: true,
+		}
+		if rs, ok := zip.(storage.SizeReadSeekCloser); ok {
+			return &seekableVerifyingZip{verifyingZip: v, rs: rs}, nil
+		}
+		return v, nil
+	}
+
+	spooled, zipHash, err := spool(zip)
+	zip.Close()
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	h, err := module.HashZip(spooled.File, spooled.size)
+	if err != nil {
+		spooled.Close()
+		// a zip that can not even be read is corrupt as well
+		return nil, errors.E(op, err, errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	if h != sum.Sum {
+		spooled.Close()
+		err := fmt.Errorf("zip hash %s does not match the stored %s", h, sum.Sum)
+		return nil, errors.E(op, err, errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	sum.ZipHash = zipHash
+	// a failed upgrade only means that the next read spools as well
+	p.s.SaveChecksum(ctx, mod, ver, sum)
+	return spooled, nil
+}
+
+// spool copies zip to a temporary file and returns
+// it along with the SHA-256 of its content
+func spool(zip io.Reader) (*spooledZip, string, error) {
+	const op errors.Op = "download.spool"
+	f, err := ioutil.TempFile("", "athens-zip")
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	s := &spooledZip{File: f}
+	h := sha256.New()
+	s.size, err = io.Copy(io.MultiWriter(f, h), io.LimitReader(zip, module.MaxZipFile+1))
+	if err == nil && s.size > module.MaxZipFile {
+		err = fmt.Errorf("zip is larger than %d bytes", module.MaxZipFile)
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		s.Close()
+		return nil, "", errors.E(op, err)
+	}
+	return s, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// spooledZip is a verified zip in a temporary file, which is
+// removed when it is closed. It implements storage.SizeReadSeekCloser.
+type spooledZip struct {
+	*os.File
+	size int64
+}
+
+func (s *spooledZip) Size() int64 {
+	return s.size
+}
+
+func (s *spooledZip) Close() error {
+	err := s.File.Close()
+	os.Remove(s.File.Name())
+	return err
+}
+
+// verifyingZip hashes a zip as it is read and holds back its last
+// byte until the hash matches want. On a mismatch reads fail with an
+// error of KindChecksumMismatch and onMismatch is called once.
+type verifyingZip struct {
+	zip        io.ReadCloser
+	h          hash.Hash
+	want       string
+	onMismatch func()
+	// verify is false once the zip is read from an offset
+	verify bool
+
+	pending []byte
+	scratch []byte
+	eof     bool
+	err     error
+}
+
+func (v *verifyingZip) Read(p []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+	if v.scratch == nil {
+		v.scratch = make([]byte, 32*1024)
+	}
+	// keep at least one byte pending until the end of the zip is reached
+	for len(v.pending) <= 1 && !v.eof {
+		n, err := v.zip.Read(v.scratch)
+		v.h.Write(v.scratch[:n])
+		v.pending = append(v.pending, v.scratch[:n]...)
+		if err == io.EOF {
+			v.eof = true
+			if got := hex.EncodeToString(v.h.Sum(nil)); v.verify && got != v.want {
+				v.err = errors.E("verifyingZip.Read", fmt.Errorf("zip hash %s does not match the stored %s", got, v.want), errors.KindChecksumMismatch)
+				v.onMismatch()
+				return 0, v.err
+			}
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	avail := v.pending
+	if !v.eof {
+		avail = avail[:len(avail)-1]
+	}
+	if len(avail) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, avail)
+	v.pending = append(v.pending[:0], v.pending[n:]...)
+	return n, nil
+}
+
+func (v *verifyingZip) Close() error {
+	return v.zip.Close()
+}
+
+// seekableVerifyingZip is a verifyingZip of a storage.SizeReadSeekCloser
+type seekableVerifyingZip struct {
+	*verifyingZip
+	rs storage.SizeReadSeekCloser
+}
+
+func (s *seekableVerifyingZip) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekCurrent {
+		// the pending bytes are read from rs but not yet from s
+		offset -= int64(len(s.pending))
+	}
+	pos, err := s.rs.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	s.pending, s.eof, s.err = s.pending[:0], false, nil
+	s.h.Reset()
+	// only reads from the start cover the whole zip
+	s.verify = pos == 0
+	return pos, nil
+}
+
+func (s *seekableVerifyingZip) Size() int64 {
+	return s.rs.Size()
+}
+
+// refetchCall is a refetch of a version in flight
+type refetchCall struct {
+	done chan struct{}
+	err  error
+}
+
+// refetches serializes the refetches of each version and
+// remembers the versions whose upstream content changed
+type refetches struct {
+	mu          sync.Mutex
+	inFlight    map[string]*refetchCall
+	quarantined map[string]error
+	// replaced is when each version was last replaced, so that
+	// reads of the copy before do not replace it once more
+	replaced map[string]time.Time
+}
+
+// waitRefetch waits for a refetch of mod@ver in flight, so that reads
+// do not see the version while it is replaced. It returns an error of
+// KindChecksumMismatch if the version is quarantined.
+func (p *protocol) waitRefetch(ctx context.Context, mod, ver string) error {
+	const op errors.Op = "protocol.waitRefetch"
+	mv := config.FmtModVer(mod, ver)
+	p.refetches.mu.Lock()
+	call := p.refetches.inFlight[mv]
+	p.refetches.mu.Unlock()
+	if call != nil {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return errors.E(op, ctx.Err())
+		}
+	}
+	p.refetches.mu.Lock()
+	defer p.refetches.mu.Unlock()
+	if err, ok := p.refetches.quarantined[mv]; ok {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// refetch replaces a stored version that failed verification with
+// a fresh copy from upstream and waits for it. See startRefetch.
+func (p *protocol) refetch(ctx context.Context, mod, ver string, readAt time.Time) error {
+	const op errors.Op = "protocol.refetch"
+	call := p.startRefetch(mod, ver, readAt)
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return errors.E(op, ctx.Err())
+	}
+	if call.err != nil {
+		return errors.E(op, call.err)
+	}
+	return nil
+}
+
+// startRefetch starts replacing a stored version that failed
+// verification when it was read at readAt with a fresh copy from
+// upstream, unless it is being replaced already or was replaced
+// after readAt. If upstream no longer serves the content the
+// version was first stored with, the original checksum is kept and
+// the version is quarantined: it fails with an error of
+// KindChecksumMismatch, without asking upstream again, until the
+// proxy restarts. A read-only or offline protocol never replaces a
+// version, it only reports the mismatch.
+func (p *protocol) startRefetch(mod, ver string, readAt time.Time) *refetchCall {
+	mv := config.FmtModVer(mod, ver)
+	p.refetches.mu.Lock()
+	defer p.refetches.mu.Unlock()
+	if call, ok := p.refetches.inFlight[mv]; ok {
+		return call
+	}
+	call := &refetchCall{done: make(chan struct{})}
+	if err, ok := p.refetches.quarantined[mv]; ok {
+		call.err = err
+		close(call.done)
+		return call
+	}
+	if p.refetches.replaced[mv].After(readAt) {
+		// the read saw the copy that was replaced since
+		close(call.done)
+		return call
+	}
+	p.refetches.inFlight[mv] = call
+	go func() {
+		// the refetch outlives the read that started it,
+		// just like the stash it runs
+		err := p.replace(context.Background(), mod, ver)
+		p.refetches.mu.Lock()
+		defer p.refetches.mu.Unlock()
+		if errors.Kind(err) == errors.KindChecksumMismatch && p.stashes() {
+			p.refetches.quarantined[mv] = err
+		}
+		if err == nil {
+			p.refetches.replaced[mv] = time.Now()
+		}
+		call.err = err
+		delete(p.refetches.inFlight, mv)
+		close(call.done)
+	}()
+	return call
+}
+
+// replace deletes mod@ver and stashes it again. The checksum
+// it was stored with is put back right after the delete, so that
+// the stasher only saves a fresh copy that matches it, and a
+// failed refetch leaves it for the next stash to compare against.
+func (p *protocol) replace(ctx context.Context, mod, ver string) error {
+	const op errors.Op = "protocol.replace"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if !p.stashes() {
+		return errors.E(op, "stored version does not match its checksum", errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	old, err := p.s.Checksum(ctx, mod, ver)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	err = p.s.Delete(ctx, mod, ver)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return errors.E(op, err)
+	}
+	if err := p.s.SaveChecksum(ctx, mod, ver, old); err != nil {
+		return errors.E(op, err)
+	}
+	if err := p.stasher.Stash(ctx, mod, ver); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
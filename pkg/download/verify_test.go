@@ -0,0 +1,277 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/stash"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	verifyMod = "github.com/athens-artifacts/verify"
+	verifyVer = "v1.0.0"
+)
+
+// countingFetcher serves the content of mockFetcher and counts the fetches
+type countingFetcher struct {
+	mockFetcher
+	fetches int
+	// err fails the fetches when it is set
+	err error
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	f.fetches++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.mockFetcher.Fetch(ctx, mod, ver)
+}
+
+func getVerifyDP(t *testing.T) (Protocol, storage.Backend, *countingFetcher) {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	f := &countingFetcher{}
+	return New(&Opts{Storage: s, Stasher: stash.New(f, s)}), s, f
+}
+
+// corrupt replaces the stored version with other
+// content, but keeps its checksum in place
+func corrupt(t *testing.T, s storage.Backend) {
+	t.Helper()
+	ctx := context.Background()
+	sum, err := s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.NoError(t, s.Delete(ctx, verifyMod, verifyVer))
+	require.NoError(t, s.SaveChecksum(ctx, verifyMod, verifyVer, sum))
+	bad := []byte("tampered")
+	require.NoError(t, s.Save(ctx, verifyMod, verifyVer, bad, bytes.NewReader(testZip(verifyMod, verifyVer, bad)), bad))
+}
+
+func TestVerifiedReads(t *testing.T) {
+	dp, _, f := getVerifyDP(t)
+	ctx := context.Background()
+	goMod, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, testGoMod(verifyMod, verifyVer), goMod)
+
+	zip, err := dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zip)
+	require.NoError(t, err)
+	require.Equal(t, testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer)), b)
+	require.Equal(t, 1, f.fetches)
+}
+
+func TestCorruptGoModIsRefetched(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	corrupt(t, s)
+
+	goMod, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, testGoMod(verifyMod, verifyVer), goMod)
+	require.Equal(t, 2, f.fetches)
+}
+
+func TestCorruptZipIsRefetched(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	corrupt(t, s)
+
+	// the zip is verified as it streams, so the
+	// corrupt copy fails before it is read whole
+	zip, err := dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zip)
+	zip.Close()
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "Read: %v", err)
+	bad := testZip(verifyMod, verifyVer, []byte("tampered"))
+	require.Equal(t, bad[:len(bad)-1], b)
+
+	// and is replaced for the next read
+	zip, err = dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	b, err = ioutil.ReadAll(zip)
+	require.NoError(t, err)
+	require.Equal(t, testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer)), b)
+	require.Equal(t, 2, f.fetches)
+}
+
+func TestZipRangeRead(t *testing.T) {
+	dp, _, _ := getVerifyDP(t)
+	ctx := context.Background()
+	zip, err := dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	defer zip.Close()
+	rs, ok := zip.(storage.SizeReadSeekCloser)
+	require.True(t, ok, "zip is seekable")
+	want := testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer))
+	require.Equal(t, int64(len(want)), rs.Size())
+
+	_, err = rs.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(rs)
+	require.NoError(t, err)
+	require.Equal(t, want[10:], b)
+
+	_, err = rs.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	b, err = ioutil.ReadAll(rs)
+	require.NoError(t, err)
+	require.Equal(t, want, b)
+}
+
+func TestLegacyChecksumIsUpgraded(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	// a checksum recorded before zips were hashed as they stream
+	sum, err := s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	zipHash := sum.ZipHash
+	sum.ZipHash = ""
+	require.NoError(t, s.SaveChecksum(ctx, verifyMod, verifyVer, sum))
+
+	zip, err := dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zip)
+	require.NoError(t, err)
+	require.NoError(t, zip.Close())
+	require.Equal(t, testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer)), b)
+
+	sum, err = s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, zipHash, sum.ZipHash)
+	require.Equal(t, 1, f.fetches)
+}
+
+func TestChangedUpstreamFails(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	sum := storage.Checksum{Sum: "h1:original", GoModSum: "h1:original"}
+	require.NoError(t, s.SaveChecksum(ctx, verifyMod, verifyVer, sum))
+
+	_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "GoMod: %v", err)
+	require.Equal(t, 2, f.fetches)
+
+	// the version is quarantined, upstream is not asked again
+	for i := 0; i < 2; i++ {
+		_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+		require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "GoMod: %v", err)
+		_, err = dp.Zip(ctx, verifyMod, verifyVer)
+		require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "Zip: %v", err)
+		_, err = dp.Info(ctx, verifyMod, verifyVer)
+		require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "Info: %v", err)
+	}
+	require.Equal(t, 2, f.fetches)
+
+	// the original checksum stays in place
+	stored, err := s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+}
+
+func TestFailedRefetchKeepsChecksum(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	sum := storage.Checksum{Sum: "h1:original", GoModSum: "h1:original"}
+	require.NoError(t, s.SaveChecksum(ctx, verifyMod, verifyVer, sum))
+
+	f.err = errors.E("upstream", "upstream is down", errors.KindNotFound)
+	_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+	require.Error(t, err)
+	require.NotEqual(t, errors.KindChecksumMismatch, errors.Kind(err), "GoMod: %v", err)
+	stored, err := s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+
+	// once upstream is back, its changed content is
+	// still compared against the original checksum
+	f.err = nil
+	_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err), "GoMod: %v", err)
+	exists, err := s.Exists(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.False(t, exists)
+	stored, err = s.Checksum(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+}
+
+func TestConcurrentRefetches(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	corrupt(t, s)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			goMod, err := dp.GoMod(ctx, verifyMod, verifyVer)
+			if assert.NoError(t, err) {
+				assert.Equal(t, testGoMod(verifyMod, verifyVer), goMod)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 2, f.fetches)
+}
+
+func TestUnverifiedVersion(t *testing.T) {
+	dp, s, f := getVerifyDP(t)
+	ctx := context.Background()
+	// versions stored before checksums were recorded are served as is
+	mod := []byte("stored without checksum")
+	require.NoError(t, s.Save(ctx, verifyMod, verifyVer, mod, bytes.NewReader(testZip(verifyMod, verifyVer, mod)), mod))
+
+	goMod, err := dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, mod, goMod)
+	zip, err := dp.Zip(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	zip.Close()
+	require.Equal(t, 0, f.fetches)
+}
+
+func TestStatAfterStash(t *testing.T) {
+	dp, _, _ := getVerifyDP(t)
+	ctx := context.Background()
+	_, err := dp.Stat(ctx, verifyMod, verifyVer)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err))
+
+	_, err = dp.GoMod(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	meta, err := dp.Stat(ctx, verifyMod, verifyVer)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer)))), meta.ZipSize)
+	require.False(t, meta.FetchedAt.IsZero())
+	require.NotEmpty(t, meta.Sum)
+	require.NotEmpty(t, meta.GoModSum)
+}
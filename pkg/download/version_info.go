@@ -21,6 +21,9 @@ func VersionInfoHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffalo
 			lggr.SystemErr(err)
 			return c.Render(errors.Kind(err), nil)
 		}
+		if redirect(c, dp, lggr, mod, ver, "info") {
+			return nil
+		}
 		info, err := dp.Info(c, mod, ver)
 		if err != nil {
 			lggr.SystemErr(errors.E(op, err, errors.M(mod), errors.V(ver)))
@@ -19,6 +19,9 @@ func VersionModuleHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffa
 			lggr.SystemErr(err)
 			return c.Render(errors.Kind(err), nil)
 		}
+		if redirect(c, dp, lggr, mod, ver, "mod") {
+			return nil
+		}
 		modBts, err := dp.GoMod(c, mod, ver)
 		if err != nil {
 			err = errors.E(op, errors.M(mod), errors.V(ver), err)
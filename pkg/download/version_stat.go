@@ -0,0 +1,33 @@
+package download
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+)
+
+// PathVersionStat URL.
+const PathVersionStat = "/{module:.+}/@v/{version}.stat"
+
+// VersionStatHandler implements GET baseURL/module/@v/version.stat
+// so that operators can see where a stored version came from
+func VersionStatHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffalo.Handler {
+	const op errors.Op = "download.VersionStatHandler"
+	return func(c buffalo.Context) error {
+		mod, ver, err := getModuleParams(c, op)
+		if err != nil {
+			lggr.SystemErr(err)
+			return c.Render(errors.Kind(err), nil)
+		}
+		meta, err := dp.Stat(c, mod, ver)
+		if err != nil {
+			lggr.SystemErr(errors.E(op, err, errors.M(mod), errors.V(ver)))
+			return c.Render(errors.Kind(err), nil)
+		}
+
+		return c.Render(http.StatusOK, eng.JSON(meta))
+	}
+}
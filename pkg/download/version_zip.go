@@ -2,17 +2,23 @@ package download
 
 import (
 	"io"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/buffalo/render"
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage"
 )
 
 // PathVersionZip URL.
 const PathVersionZip = "/{module:.+}/@v/{version}.zip"
 
-// VersionZipHandler implements GET baseURL/module/@v/version.zip
+// VersionZipHandler implements GET and HEAD baseURL/module/@v/version.zip.
+// When the storage returns a storage.SizeReadSeekCloser, Range and
+// If-Range requests are answered with partial content.
 func VersionZipHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffalo.Handler {
 	const op errors.Op = "download.VersionZipHandler"
 
@@ -22,6 +28,9 @@ func VersionZipHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffalo.
 			lggr.SystemErr(err)
 			return c.Render(errors.Kind(err), nil)
 		}
+		if redirect(c, dp, lggr, mod, ver, "zip") {
+			return nil
+		}
 		zip, err := dp.Zip(c, mod, ver)
 		if err != nil {
 			lggr.SystemErr(err)
@@ -29,10 +38,37 @@ func VersionZipHandler(dp Protocol, lggr log.Entry, eng *render.Engine) buffalo.
 		}
 		defer zip.Close()
 
+		w := c.Response()
+		w.Header().Set("Content-Type", "application/zip")
+		// versions stashed before metadata was recorded have none
+		meta, err := dp.Stat(c, mod, ver)
+		if err == nil && meta.Sum != "" {
+			// the go.sum hash identifies the content, so If-Range
+			// requests can be validated against it
+			w.Header().Set("ETag", strconv.Quote(meta.Sum))
+		}
+
+		if rs, ok := zip.(storage.SizeReadSeekCloser); ok {
+			// ServeContent handles HEAD, Range and If-Range
+			// and writes the status and length itself
+			var modTime time.Time
+			if err == nil {
+				modTime = meta.FetchedAt
+			}
+			http.ServeContent(w, c.Request(), "", modTime, rs)
+			return nil
+		}
+
+		if err == nil && meta.ZipSize > 0 {
+			w.Header().Set("Content-Length", strconv.FormatInt(meta.ZipSize, 10))
+		}
 		// Calling c.Response().Write will write the header directly
 		// and we would get a 0 status in the buffalo logs.
 		c.Render(200, nil)
-		_, err = io.Copy(c.Response(), zip)
+		if c.Request().Method == http.MethodHead {
+			return nil
+		}
+		_, err = io.Copy(w, zip)
 		if err != nil {
 			lggr.SystemErr(errors.E(op, errors.M(mod), errors.V(ver), err))
 		}
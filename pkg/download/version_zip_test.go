@@ -0,0 +1,74 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func zipApp(t *testing.T) *buffalo.App {
+	t.Helper()
+	dp, _, _ := getVerifyDP(t)
+	app := buffalo.New(buffalo.Options{})
+	RegisterHandlers(app, &HandlerOpts{
+		Protocol: dp,
+		Logger:   log.New("none", logrus.PanicLevel),
+		Engine:   render.New(render.Options{}),
+	})
+	return app
+}
+
+func getZip(t *testing.T, app *buffalo.App, method string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest(method, "/"+verifyMod+"/@v/"+verifyVer+".zip", nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+	app.ServeHTTP(w, r)
+	return w
+}
+
+func TestZipRanges(t *testing.T) {
+	app := zipApp(t)
+	zip := testZip(verifyMod, verifyVer, []byte(verifyMod+"@"+verifyVer))
+	size := strconv.Itoa(len(zip))
+
+	w := getZip(t, app, "GET", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, size, w.Header().Get("Content-Length"))
+	require.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	require.Equal(t, zip, w.Body.Bytes())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	w = getZip(t, app, "HEAD", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, size, w.Header().Get("Content-Length"))
+	require.Empty(t, w.Body.Bytes())
+
+	w = getZip(t, app, "GET", map[string]string{"Range": "bytes=10-"})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "bytes 10-"+strconv.Itoa(len(zip)-1)+"/"+size, w.Header().Get("Content-Range"))
+	require.Equal(t, zip[10:], w.Body.Bytes())
+
+	w = getZip(t, app, "GET", map[string]string{"Range": "bytes=10-", "If-Range": etag})
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, zip[10:], w.Body.Bytes())
+
+	// a stale validator gets the whole zip
+	w = getZip(t, app, "GET", map[string]string{"Range": "bytes=10-", "If-Range": `"h1:stale"`})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, zip, w.Body.Bytes())
+
+	w = getZip(t, app, "GET", map[string]string{"Range": "bytes=" + size + "-"})
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+}
@@ -11,11 +11,14 @@ import (
 
 // Kind enums
 const (
-	KindNotFound      = http.StatusNotFound
-	KindBadRequest    = http.StatusBadRequest
-	KindUnexpected    = http.StatusInternalServerError
-	KindAlreadyExists = http.StatusConflict
-	KindRateLimit     = http.StatusTooManyRequests
+	KindNotFound         = http.StatusNotFound
+	KindBadRequest       = http.StatusBadRequest
+	KindUnexpected       = http.StatusInternalServerError
+	KindAlreadyExists    = http.StatusConflict
+	KindRateLimit        = http.StatusTooManyRequests
+	KindChecksumMismatch = http.StatusUnprocessableEntity
+	KindForbidden        = http.StatusForbidden
+	KindNotImplemented   = http.StatusNotImplemented
 )
 
 // Error is an Athens system error.
@@ -5,7 +5,11 @@ import (
 )
 
 // IsRepoNotFoundErr returns true if the Go command line
-// hints at a repository not found.
+// hints at a repository not found, or if an upstream
+// proxy reported the module as not found.
 func IsRepoNotFoundErr(err error) bool {
+	if Kind(err) == KindNotFound {
+		return true
+	}
 	return strings.Contains(err.Error(), "remote: Repository not found")
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/module"
 	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/semver"
 )
 
 // NewFilterMiddleware builds a middleware function that implements the filters configured in
@@ -29,7 +30,7 @@ func NewFilterMiddleware(mf *module.Filter, olympusEndpoint string) buffalo.Midd
 			// i.e. list requests path is like /{module:.+}/@v/list with no version parameter
 			version, _ := paths.GetVersion(c)
 
-			if isPseudoVersion(version) {
+			if semver.IsPseudo(version) {
 				return next(c)
 			}
 
@@ -50,10 +51,6 @@ func NewFilterMiddleware(mf *module.Filter, olympusEndpoint string) buffalo.Midd
 	}
 }
 
-func isPseudoVersion(version string) bool {
-	return strings.HasPrefix(version, "v0.0.0-")
-}
-
 func redirectToOlympusURL(olympusEndpoint string, u *url.URL) string {
 	return strings.TrimSuffix(olympusEndpoint, "/") + u.Path
 }
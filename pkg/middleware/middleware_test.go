@@ -75,6 +75,19 @@ func Test_FilterMiddleware(t *testing.T) {
 	// Private, the proxy is working and returns a 200
 	res = w.Request("/github.com/athens-artifacts/happy-path/@v/list").Get()
 	r.Equal(200, res.Code)
+
+	// Pseudo-versions of all three forms are not filtered
+	for _, ver := range []string{
+		"v0.0.0-20180801120000-0123456789ab",
+		"v1.2.4-0.20180801120000-0123456789ab",
+		"v1.2.3-pre.0.20180801120000-0123456789ab",
+	} {
+		res = w.Request("/github.com/athens-artifacts/no-tags/@v/" + ver + ".info").Get()
+		r.Equal(200, res.Code, ver)
+	}
+	// but versions that only look like them are
+	res = w.Request("/github.com/athens-artifacts/no-tags/@v/v0.0.0-pre.info").Get()
+	r.Equal(403, res.Code)
 }
 
 func hookFilterApp(hook string) *buffalo.App {
@@ -0,0 +1,42 @@
+package module
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+type chainFetcher struct {
+	fetchers []Fetcher
+}
+
+// NewChainFetcher creates a fetcher which tries fetchers in order,
+// with the same rules the go command uses for the GOPROXY list:
+// a fetcher that does not have a module version, and returns an
+// error of KindNotFound, falls through to the next one, but any
+// other error stops the chain and fails the fetch.
+func NewChainFetcher(fetchers ...Fetcher) Fetcher {
+	return &chainFetcher{fetchers: fetchers}
+}
+
+// Fetch returns the module version from the first fetcher that has it
+func (c *chainFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	const op errors.Op = "chainFetcher.Fetch"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	for _, f := range c.fetchers {
+		v, err := f.Fetch(ctx, mod, ver)
+		if errors.IsNotFoundErr(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return v, nil
+	}
+	err := fmt.Errorf("no upstream has %s@%s", mod, ver)
+	return nil, errors.E(op, err, errors.M(mod), errors.V(ver), errors.KindNotFound)
+}
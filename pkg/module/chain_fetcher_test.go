@@ -0,0 +1,56 @@
+package module
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// stubFetcher returns err, or a version with its name as the .info file
+type stubFetcher struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (f *stubFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &storage.Version{Info: []byte(f.name)}, nil
+}
+
+func (s *ModuleSuite) TestChainFetcherFallsThroughNotFound() {
+	r := s.Require()
+	internal := &stubFetcher{err: errors.E("internal", errors.KindNotFound)}
+	public := &stubFetcher{name: "public"}
+	direct := &stubFetcher{name: "direct"}
+
+	v, err := NewChainFetcher(internal, public, direct).Fetch(ctx, repoURI, version)
+	r.NoError(err)
+	r.Equal("public", string(v.Info))
+	r.Equal(1, internal.calls)
+	r.Equal(0, direct.calls)
+}
+
+func (s *ModuleSuite) TestChainFetcherStopsOnError() {
+	r := s.Require()
+	internal := &stubFetcher{err: errors.E("internal", "unavailable")}
+	public := &stubFetcher{name: "public"}
+
+	_, err := NewChainFetcher(internal, public).Fetch(ctx, repoURI, version)
+	r.Error(err)
+	r.False(errors.IsNotFoundErr(err))
+	r.Equal(0, public.calls)
+}
+
+func (s *ModuleSuite) TestChainFetcherNotFound() {
+	r := s.Require()
+	internal := &stubFetcher{err: errors.E("internal", errors.KindNotFound)}
+	public := &stubFetcher{err: errors.E("public", errors.KindNotFound)}
+
+	_, err := NewChainFetcher(internal, public).Fetch(ctx, repoURI, version)
+	r.True(errors.IsNotFoundErr(err))
+}
@@ -0,0 +1,79 @@
+package module
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// HashGoMod returns the go.sum hash (h1:...) of a go.mod file,
+// as it appears on the "module version/go.mod" line of go.sum.
+func HashGoMod(mod []byte) (string, error) {
+	const op errors.Op = "module.HashGoMod"
+	open := func(string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(mod)), nil
+	}
+	h, err := hash1([]string{"go.mod"}, open)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return h, nil
+}
+
+// HashZip returns the go.sum hash (h1:...) of a module zip,
+// as it appears on the "module version" line of go.sum.
+func HashZip(r io.ReaderAt, size int64) (string, error) {
+	const op errors.Op = "module.HashZip"
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	files := make([]string, 0, len(z.File))
+	zfiles := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		files = append(files, f.Name)
+		zfiles[f.Name] = f
+	}
+	open := func(name string) (io.ReadCloser, error) {
+		return zfiles[name].Open()
+	}
+	h, err := hash1(files, open)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return h, nil
+}
+
+// hash1 implements the "h1:" hash of cmd/go: the base64 encoded
+// SHA-256 of a summary listing the SHA-256 of every file
+// next to its name, sorted by name.
+func hash1(files []string, open func(string) (io.ReadCloser, error)) (string, error) {
+	h := sha256.New()
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", fmt.Errorf("file name %q contains a newline", file)
+		}
+		r, err := open(file)
+		if err != nil {
+			return "", err
+		}
+		hf := sha256.New()
+		_, err = io.Copy(hf, r)
+		r.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%x  %s\n", hf.Sum(nil), file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,69 @@
+package module
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+func (s *ModuleSuite) TestHashGoMod() {
+	r := s.Require()
+	// as recorded in go.sum files for the synthesized go.mod of github.com/pkg/errors
+	h, err := HashGoMod([]byte("module github.com/pkg/errors\n"))
+	r.NoError(err)
+	r.Equal("h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=", h)
+}
+
+func (s *ModuleSuite) TestHashZip() {
+	r := s.Require()
+	files := map[string]string{
+		"example.com/mod@v1.0.0/go.mod": "module example.com/mod\n",
+		"example.com/mod@v1.0.0/mod.go": "package mod\n",
+	}
+	// the hash must not depend on the order of the zip entries
+	z1 := testZip(s, files, "example.com/mod@v1.0.0/go.mod", "example.com/mod@v1.0.0/mod.go")
+	z2 := testZip(s, files, "example.com/mod@v1.0.0/mod.go", "example.com/mod@v1.0.0/go.mod")
+
+	h1, err := HashZip(bytes.NewReader(z1), int64(len(z1)))
+	r.NoError(err)
+	h2, err := HashZip(bytes.NewReader(z2), int64(len(z2)))
+	r.NoError(err)
+	r.Equal(h1, h2)
+
+	summary := ""
+	for _, name := range []string{"example.com/mod@v1.0.0/go.mod", "example.com/mod@v1.0.0/mod.go"} {
+		summary += fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte(files[name])), name)
+	}
+	sum := sha256.Sum256([]byte(summary))
+	r.Equal("h1:"+base64.StdEncoding.EncodeToString(sum[:]), h1)
+
+	files["example.com/mod@v1.0.0/mod.go"] = "package mod // changed\n"
+	z3 := testZip(s, files, "example.com/mod@v1.0.0/go.mod", "example.com/mod@v1.0.0/mod.go")
+	h3, err := HashZip(bytes.NewReader(z3), int64(len(z3)))
+	r.NoError(err)
+	r.NotEqual(h1, h3)
+}
+
+func (s *ModuleSuite) TestHashZipInvalid() {
+	_, err := HashZip(bytes.NewReader([]byte("not a zip")), 9)
+	s.Require().Error(err)
+}
+
+func testZip(s *ModuleSuite, files map[string]string, order ...string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, name := range order {
+		w, err := zw.Create(name)
+		s.Require().NoError(err)
+		if strings.HasSuffix(name, "/") {
+			continue
+		}
+		_, err = w.Write([]byte(files[name]))
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(zw.Close())
+	return buf.Bytes()
+}
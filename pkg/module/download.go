@@ -2,6 +2,7 @@ package module
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -10,9 +11,9 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
 	"github.com/gomods/athens/pkg/storage"
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -26,8 +27,14 @@ func Download(ctx context.Context, timeout time.Duration, baseURL, module, versi
 	const op errors.Op = "module.Download"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	// the zip is read after Download returns,
+	// so tctx is canceled when the zip is closed
 	tctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	encVer, err := paths.EncodePath(version)
+	if err != nil {
+		cancel()
+		return nil, errors.E(op, err)
+	}
 
 	var info []byte
 	var infoErr error
@@ -43,7 +50,7 @@ func Download(ctx context.Context, timeout time.Duration, baseURL, module, versi
 
 	go func() {
 		defer wg.Done()
-		infoReq, err := getRequest(tctx, baseURL, module, version, ".info")
+		infoReq, err := getRequest(tctx, baseURL, module, "@v/"+encVer+".info")
 		if err != nil {
 			info, infoErr = nil, err
 			return
@@ -58,7 +65,7 @@ func Download(ctx context.Context, timeout time.Duration, baseURL, module, versi
 
 	go func() {
 		defer wg.Done()
-		modReq, err := getRequest(tctx, baseURL, module, version, ".mod")
+		modReq, err := getRequest(tctx, baseURL, module, "@v/"+encVer+".mod")
 		if err != nil {
 			mod, modErr = nil, err
 			return
@@ -73,7 +80,7 @@ func Download(ctx context.Context, timeout time.Duration, baseURL, module, versi
 
 	go func() {
 		defer wg.Done()
-		zipReq, err := getRequest(tctx, baseURL, module, version, ".zip")
+		zipReq, err := getRequest(tctx, baseURL, module, "@v/"+encVer+".zip")
 		if err != nil {
 			zip, zipErr = nil, err
 			return
@@ -93,13 +100,23 @@ func Download(ctx context.Context, timeout time.Duration, baseURL, module, versi
 		errs = multierror.Append(errs, zipErr)
 	}
 	if errs != nil {
-		return nil, errors.E(op, errs)
+		if zip != nil {
+			zip.Close()
+		}
+		cancel()
+		for _, err := range []error{infoErr, modErr, zipErr} {
+			if errors.IsNotFoundErr(err) {
+				return nil, errors.E(op, errs, errors.M(module), errors.V(version), errors.KindNotFound)
+			}
+		}
+		return nil, errors.E(op, errs, errors.M(module), errors.V(version))
 	}
 
 	ver := storage.Version{
-		Info: info,
-		Mod:  mod,
-		Zip:  zip,
+		Info:   info,
+		Mod:    mod,
+		Zip:    &cancelReadCloser{ReadCloser: zip, cancel: cancel},
+		Origin: baseURL,
 	}
 	return &ver, nil
 }
@@ -109,6 +126,18 @@ func getBytes(rb io.ReadCloser) ([]byte, error) {
 	return ioutil.ReadAll(rb)
 }
 
+// cancelReadCloser cancels the context of
+// the request it reads from when it is closed
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
 func getResBody(req *http.Request, timeout time.Duration) (io.ReadCloser, error) {
 	const op errors.Op = "module.getResBody"
 	client := http.Client{Timeout: timeout}
@@ -116,14 +145,47 @@ func getResBody(req *http.Request, timeout time.Duration) (io.ReadCloser, error)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		err := fmt.Errorf("%s: %s", req.URL, res.Status)
+		// proxies answer 404 or 410 for what they do not have
+		if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+			return nil, errors.E(op, err, errors.KindNotFound)
+		}
+		return nil, errors.E(op, err)
+	}
 	return res.Body, nil
 }
 
-func getRequest(ctx context.Context, baseURL, module, version, ext string) (*http.Request, error) {
+// DownloadFile downloads a file of module that is not part of a
+// version, such as "@v/list" or "@latest", from the proxy at baseURL.
+// It returns an error of KindNotFound if the proxy does not have it.
+func DownloadFile(ctx context.Context, timeout time.Duration, baseURL, module, file string) ([]byte, error) {
+	const op errors.Op = "module.DownloadFile"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := getRequest(tctx, baseURL, module, file)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	body, err := getResBody(req, timeout)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	b, err := getBytes(body)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	return b, nil
+}
+
+func getRequest(ctx context.Context, baseURL, module, file string) (*http.Request, error) {
 	const op errors.Op = "module.getRequest"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
-	u, err := join(baseURL, module, version, ext)
+	u, err := join(baseURL, module, file)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -135,13 +197,20 @@ func getRequest(ctx context.Context, baseURL, module, version, ext string) (*htt
 	return req, nil
 }
 
-func join(baseURL string, module, version, ext string) (string, error) {
+// join returns the URL of file below the
+// encoded path of module on the proxy at baseURL
+func join(baseURL string, module, file string) (string, error) {
 	const op errors.Op = "module.join"
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return "", errors.E(op, err)
 	}
-	packageVersionedName := config.PackageVersionedName(module, version, ext)
-	u.Path = path.Join(u.Path, packageVersionedName)
+	encMod, err := paths.EncodePath(module)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	// keep the exclamation marks of the encoding unescaped
+	u.Path = path.Join(u.Path, encMod, file)
+	u.RawPath = u.Path
 	return u.String(), nil
 }
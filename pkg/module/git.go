@@ -0,0 +1,213 @@
+package module
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// RepoFunc returns the URL of the git repository that holds the module
+// mod, and the directory of the module within the repository, which
+// is empty for a module at the repository root.
+type RepoFunc func(mod string) (repoURL, dir string, err error)
+
+// DefaultRepo resolves modules hosted on github.com, gitlab.com
+// and bitbucket.org to their https repository URLs. Modules of other
+// hosts are not found.
+func DefaultRepo(mod string) (string, string, error) {
+	const op errors.Op = "module.DefaultRepo"
+	parts := strings.Split(mod, "/")
+	switch parts[0] {
+	case "github.com", "gitlab.com", "bitbucket.org":
+	default:
+		return "", "", errors.E(op, fmt.Sprintf("unknown git host of %s", mod), errors.M(mod), errors.KindNotFound)
+	}
+	if len(parts) < 3 {
+		return "", "", errors.E(op, fmt.Sprintf("invalid module path %s", mod), errors.M(mod), errors.KindNotFound)
+	}
+	repo := "https://" + strings.Join(parts[:3], "/")
+	dir := strings.Join(parts[3:], "/")
+	if major := pathMajor(mod); major != "" {
+		dir = strings.TrimSuffix(strings.TrimSuffix(dir, major), "/")
+	}
+	return repo, dir, nil
+}
+
+// pathMajor returns the major version suffix of the module path mod,
+// such as "v2" for example.com/mod/v2, or "" if it has none
+func pathMajor(mod string) string {
+	i := strings.LastIndex(mod, "/")
+	last := mod[i+1:]
+	if len(last) < 2 || last[0] != 'v' || last == "v0" || last == "v1" || last[1] == '0' {
+		return ""
+	}
+	for _, r := range last[1:] {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return last
+}
+
+// gitRepo runs git commands in a bare repository
+type gitRepo struct {
+	gitBinaryName string
+	dir           string
+}
+
+func (r *gitRepo) run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, r.gitBinaryName, args...)
+	cmd.Dir = r.dir
+	// never wait for credentials on a terminal
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("git %s: %v: %s", args[0], err, stderr)
+		if isLimitHit(err.Error()) {
+			return nil, errors.E("gitRepo.run", err, errors.KindRateLimit)
+		}
+		if isMissingRepo(err.Error()) {
+			return nil, errors.E("gitRepo.run", err, errors.KindNotFound)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// commitTime returns the committer time of commit as seconds since the epoch
+func (r *gitRepo) commitTime(ctx context.Context, commit string) (int64, error) {
+	out, err := r.run(ctx, "log", "-1", "--format=%ct", commit)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+}
+
+// exists reports whether file is a regular file in commit
+func (r *gitRepo) exists(ctx context.Context, commit, file string) bool {
+	out, err := r.run(ctx, "ls-tree", "--full-tree", commit, "--", file)
+	return err == nil && strings.HasPrefix(string(out), "100")
+}
+
+// gitFile is a blob in a git tree
+type gitFile struct {
+	mode string
+	oid  string
+	path string
+}
+
+// files returns the files in the tree of commit below dir,
+// or all of them if dir is empty, in the order of git
+func (r *gitRepo) files(ctx context.Context, commit, dir string) ([]gitFile, error) {
+	args := []string{"ls-tree", "-r", "-z", "--full-tree", commit}
+	if dir != "" {
+		args = append(args, "--", dir)
+	}
+	out, err := r.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	var files []gitFile
+	for _, entry := range strings.Split(string(out), "\x00") {
+		if entry == "" {
+			continue
+		}
+		tab := strings.Index(entry, "\t")
+		if tab < 0 {
+			return nil, fmt.Errorf("unexpected git ls-tree output %q", entry)
+		}
+		fields := strings.Fields(entry[:tab])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected git ls-tree output %q", entry)
+		}
+		files = append(files, gitFile{mode: fields[0], oid: fields[2], path: entry[tab+1:]})
+	}
+	return files, nil
+}
+
+// blobs calls fn with the contents of the blobs oids, in order
+func (r *gitRepo) blobs(ctx context.Context, oids []string, fn func(oid string, content io.Reader) error) error {
+	if len(oids) == 0 {
+		return nil
+	}
+	cmd := exec.CommandContext(ctx, r.gitBinaryName, "cat-file", "--batch")
+	cmd.Dir = r.dir
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	br := bufio.NewReader(stdout)
+	for _, oid := range oids {
+		err = readBlob(br, oid, fn)
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git cat-file: %v: %s", err, stderr)
+	}
+	return nil
+}
+
+// readBlob reads one "<oid> <type> <size>\n<content>\n" record of git cat-file --batch
+func readBlob(br *bufio.Reader, oid string, fn func(oid string, content io.Reader) error) error {
+	header, err := br.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 3 || fields[0] != oid || fields[1] != "blob" {
+		return fmt.Errorf("unexpected git cat-file output %q", header)
+	}
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return err
+	}
+	content := io.LimitReader(br, size)
+	if err := fn(oid, content); err != nil {
+		return err
+	}
+	// skip what fn did not read and the trailing newline
+	if _, err := io.Copy(ioutil.Discard, content); err != nil {
+		return err
+	}
+	_, err = br.Discard(1)
+	return err
+}
+
+func isMissingRepo(o string) bool {
+	return strings.Contains(o, "Repository not found") ||
+		strings.Contains(o, "does not appear to be a git repository") ||
+		strings.Contains(o, "repository not found")
+}
+
+func validGitBinary(name string) error {
+	const op errors.Op = "module.validGitBinary"
+	if err := exec.Command(name, "--version").Run(); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
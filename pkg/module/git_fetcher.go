@@ -0,0 +1,361 @@
+package module
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+type gitFetcher struct {
+	fs            afero.Fs
+	gitBinaryName string
+	repo          RepoFunc
+}
+
+// NewGitFetcher creates a fetcher which fetches modules straight from
+// their git repositories, without the go command. repo resolves module
+// paths to repositories and defaults to DefaultRepo. The .info, .mod and
+// .zip files it builds are the same as the ones of go mod download.
+func NewGitFetcher(gitBinaryName string, fs afero.Fs, repo RepoFunc) (Fetcher, error) {
+	const op errors.Op = "module.NewGitFetcher"
+	if err := validGitBinary(gitBinaryName); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if repo == nil {
+		repo = DefaultRepo
+	}
+	return &gitFetcher{fs: fs, gitBinaryName: gitBinaryName, repo: repo}, nil
+}
+
+// Fetch fetches the commit of a module version into a temporary bare
+// repository and returns the corresponding .info, .mod, and .zip files.
+// ver is either a semantic version tag, a pseudo-version, or any other
+// git revision such as a branch, whose pseudo-version is then computed.
+func (g *gitFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	const op errors.Op = "gitFetcher.Fetch"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+
+	repoURL, dir, err := g.repo(mod)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	root, err := afero.TempDir(g.fs, "", "athens-git")
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	r := &gitRepo{gitBinaryName: g.gitBinaryName, dir: root}
+	v, err := g.fetch(ctx, r, repoURL, dir, mod, ver)
+	if err != nil {
+		ClearFiles(g.fs, root)
+		return nil, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	return v, nil
+}
+
+func (g *gitFetcher) fetch(ctx context.Context, r *gitRepo, repoURL, dir, mod, ver string) (*storage.Version, error) {
+	const op errors.Op = "gitFetcher.fetch"
+	if _, err := r.run(ctx, "init", "--bare", "-q"); err != nil {
+		return nil, errors.E(op, err)
+	}
+	major := pathMajor(mod)
+	tagPrefix := ""
+	if dir != "" {
+		tagPrefix = dir + "/"
+	}
+	commit, version, err := g.resolve(ctx, r, repoURL, tagPrefix, major, ver)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	secs, err := r.commitTime(ctx, commit)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	commitTime := time.Unix(secs, 0).UTC()
+	if semver.IsPseudo(version) {
+		t, err := semver.PseudoTime(version)
+		if err != nil || !t.Equal(commitTime) {
+			return nil, errors.E(op, fmt.Sprintf("%s does not match the time of commit %s", version, commit), errors.KindNotFound)
+		}
+	}
+
+	// a module with a major version suffix lives either in
+	// dir or in its subdirectory named after the major version
+	codeDir := dir
+	if major != "" && r.exists(ctx, commit, path.Join(dir, major, "go.mod")) {
+		codeDir = path.Join(dir, major)
+	}
+	modFile := path.Join(codeDir, "go.mod")
+	hasMod := r.exists(ctx, commit, modFile)
+	if err := checkMajor(version, major, hasMod); err != nil {
+		return nil, errors.E(op, err, errors.KindNotFound)
+	}
+
+	gomod := []byte(fmt.Sprintf("module %s\n", mod))
+	if hasMod {
+		gomod, err = r.run(ctx, "cat-file", "blob", commit+":"+modFile)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+	info, err := json.Marshal(storage.RevInfo{Version: version, Time: commitTime})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	zipPath := filepath.Join(r.dir, "source.zip")
+	if err := g.writeZip(ctx, r, zipPath, commit, codeDir, mod, version); err != nil {
+		return nil, errors.E(op, err)
+	}
+	zf, err := g.fs.Open(zipPath)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	fi, err := zf.Stat()
+	if err != nil {
+		zf.Close()
+		return nil, errors.E(op, err)
+	}
+	sum, err := HashZip(zf, fi.Size())
+	if err != nil {
+		zf.Close()
+		return nil, errors.E(op, err)
+	}
+	if _, err := zf.Seek(0, io.SeekStart); err != nil {
+		zf.Close()
+		return nil, errors.E(op, err)
+	}
+	modSum, err := HashGoMod(gomod)
+	if err != nil {
+		zf.Close()
+		return nil, errors.E(op, err)
+	}
+
+	return &storage.Version{
+		Info:     info,
+		Mod:      gomod,
+		Zip:      &zipReadCloser{zf, g.fs, r.dir},
+		Sum:      sum,
+		GoModSum: modSum,
+		Origin:   repoURL,
+	}, nil
+}
+
+// resolve fetches the commit of ver from repoURL and
+// returns its hash along with the version of the module
+func (g *gitFetcher) resolve(ctx context.Context, r *gitRepo, repoURL, tagPrefix, major, ver string) (string, string, error) {
+	const op errors.Op = "gitFetcher.resolve"
+	if ver == "" || strings.HasPrefix(ver, "-") {
+		return "", "", errors.E(op, fmt.Sprintf("invalid version %q", ver), errors.KindNotFound)
+	}
+
+	if semver.IsValid(ver) && !semver.IsPseudo(ver) {
+		tag := tagPrefix + strings.TrimSuffix(ver, "+incompatible")
+		ref := "refs/tags/" + tag
+		out, err := r.run(ctx, "ls-remote", repoURL, ref)
+		if err != nil {
+			return "", "", errors.E(op, err)
+		}
+		if !strings.Contains(string(out), "\t"+ref+"\n") {
+			return "", "", errors.E(op, fmt.Sprintf("unknown tag %s", tag), errors.KindNotFound)
+		}
+		if _, err := r.run(ctx, "fetch", "-q", "--depth=1", repoURL, "+"+ref+":"+ref); err != nil {
+			return "", "", errors.E(op, err)
+		}
+		commit, err := r.run(ctx, "rev-parse", "--verify", ref+"^{commit}")
+		if err != nil {
+			return "", "", errors.E(op, err)
+		}
+		return strings.TrimSpace(string(commit)), ver, nil
+	}
+
+	if _, err := r.run(ctx, "fetch", "-q", repoURL, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return "", "", errors.E(op, err)
+	}
+	rev := ver
+	if semver.IsPseudo(ver) {
+		rev, _ = semver.PseudoRev(ver)
+	}
+	out, err := r.run(ctx, "rev-parse", "--verify", "-q", rev+"^{commit}")
+	if err != nil {
+		return "", "", errors.E(op, fmt.Sprintf("unknown revision %s", rev), errors.KindNotFound)
+	}
+	commit := strings.TrimSpace(string(out))
+	if semver.IsPseudo(ver) {
+		return commit, ver, nil
+	}
+
+	// a tagged commit has the version of its tag, any other
+	// commit the pseudo-version based on the closest older tag
+	out, err = r.run(ctx, "tag", "--points-at", commit, "--list", tagPrefix+"v*")
+	if err != nil {
+		return "", "", errors.E(op, err)
+	}
+	for _, tag := range strings.Fields(string(out)) {
+		if v := strings.TrimPrefix(tag, tagPrefix); tagMatches(v, major) {
+			return commit, v, nil
+		}
+	}
+	older := ""
+	out, err = r.run(ctx, "describe", "--tags", "--abbrev=0", "--match", tagPrefix+"v*", commit)
+	if err == nil {
+		if v := strings.TrimPrefix(strings.TrimSpace(string(out)), tagPrefix); tagMatches(v, major) {
+			older = v
+		}
+	}
+	secs, err := r.commitTime(ctx, commit)
+	if err != nil {
+		return "", "", errors.E(op, err)
+	}
+	return commit, semver.PseudoVersion(major, older, time.Unix(secs, 0), commit[:12]), nil
+}
+
+// tagMatches reports whether the tag v is a version
+// of a module path with the major version suffix major
+func tagMatches(v, major string) bool {
+	if !semver.IsValid(v) || semver.IsPseudo(v) || strings.Contains(v, "+") {
+		return false
+	}
+	if major == "" {
+		return semver.Major(v) == "v0" || semver.Major(v) == "v1"
+	}
+	return semver.Major(v) == major
+}
+
+// checkMajor checks that version is a valid version of a module path with
+// the major version suffix major, whose go.mod file exists if hasMod is set.
+// Versions from v2 on of modules without the suffix must be +incompatible,
+// which is only valid for modules without a go.mod file.
+func checkMajor(version, major string, hasMod bool) error {
+	incompatible := strings.HasSuffix(version, "+incompatible")
+	vMajor := semver.Major(version)
+	switch {
+	case major != "" && vMajor != major:
+		return fmt.Errorf("version %s does not match the major version %s of the module path", version, major)
+	case major != "" && incompatible:
+		return fmt.Errorf("version %s is invalid for a module with a major version suffix", version)
+	case major == "" && vMajor != "v0" && vMajor != "v1" && !incompatible:
+		return fmt.Errorf("version %s requires +incompatible or a /%s module path", version, vMajor)
+	case incompatible && hasMod:
+		return fmt.Errorf("version %s is +incompatible but has a go.mod file", version)
+	}
+	return nil
+}
+
+// writeZip writes the module zip of the files of codeDir in commit
+// to zipPath, with the same entries and in the same order as cmd/go.
+func (g *gitFetcher) writeZip(ctx context.Context, r *gitRepo, zipPath, commit, codeDir, mod, version string) error {
+	const op errors.Op = "gitFetcher.writeZip"
+	files, err := r.files(ctx, commit, codeDir)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	prefix := ""
+	if codeDir != "" {
+		prefix = codeDir + "/"
+	}
+
+	// directories with a go.mod file hold other modules
+	var submodules []string
+	for _, f := range files {
+		name := strings.TrimPrefix(f.path, prefix)
+		if strings.HasSuffix(name, "/go.mod") {
+			submodules = append(submodules, strings.TrimSuffix(name, "go.mod"))
+		}
+	}
+
+	var entries []gitFile
+	haveLICENSE := false
+	for _, f := range files {
+		if !strings.HasPrefix(f.path, prefix) || !isRegular(f.mode) {
+			continue
+		}
+		name := strings.TrimPrefix(f.path, prefix)
+		if inSubmodule(name, submodules) || isVendoredPackage(name) {
+			continue
+		}
+		if name == "LICENSE" {
+			haveLICENSE = true
+		}
+		entries = append(entries, gitFile{mode: f.mode, oid: f.oid, path: name})
+	}
+	// modules in subdirectories get the LICENSE of the repository
+	if !haveLICENSE && codeDir != "" {
+		license, err := r.files(ctx, commit, "LICENSE")
+		if err != nil {
+			return errors.E(op, err)
+		}
+		for _, f := range license {
+			if f.path == "LICENSE" && isRegular(f.mode) {
+				entries = append(entries, f)
+			}
+		}
+	}
+
+	zf, err := g.fs.Create(zipPath)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer zf.Close()
+	zw := zip.NewWriter(zf)
+	oids := make([]string, len(entries))
+	for i, e := range entries {
+		oids[i] = e.oid
+	}
+	i := 0
+	err = r.blobs(ctx, oids, func(oid string, content io.Reader) error {
+		w, err := zw.Create(mod + "@" + version + "/" + entries[i].path)
+		i++
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, content)
+		return err
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := zw.Close(); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+func isRegular(mode string) bool {
+	return mode == "100644" || mode == "100755"
+}
+
+func inSubmodule(name string, submodules []string) bool {
+	for _, dir := range submodules {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVendoredPackage reports whether name is a file of a vendored package,
+// including the offset quirk of cmd/go that the zip hashes depend on.
+func isVendoredPackage(name string) bool {
+	var i int
+	if strings.HasPrefix(name, "vendor/") {
+		i += len("vendor/")
+	} else if j := strings.Index(name, "/vendor/"); j >= 0 {
+		i += len("/vendor/")
+	} else {
+		return false
+	}
+	return strings.Contains(name[i:], "/")
+}
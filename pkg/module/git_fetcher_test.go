@@ -0,0 +1,216 @@
+package module
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/semver"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+const gitMod = "example.com/repo"
+
+// gitRepoFixture creates a bare git repository with two commits, the first
+// tagged v1.0.0 and sub/v0.1.0, and returns a RepoFunc resolving example.com/repo
+// and its nested module example.com/repo/sub to it
+func (s *ModuleSuite) gitRepoFixture() (RepoFunc, string) {
+	r := s.Require()
+	tmp, err := ioutil.TempDir("", "athens-git-test")
+	r.NoError(err)
+	work := filepath.Join(tmp, "work")
+	bare := filepath.Join(tmp, "bare.git")
+
+	date := "2018-08-01T12:00:00Z"
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=athens", "GIT_AUTHOR_EMAIL=athens@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=athens", "GIT_COMMITTER_EMAIL=athens@example.com", "GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.CombinedOutput()
+		r.NoError(err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		p := filepath.Join(work, name)
+		r.NoError(os.MkdirAll(filepath.Dir(p), 0755))
+		r.NoError(ioutil.WriteFile(p, []byte(content), 0644))
+	}
+
+	r.NoError(os.MkdirAll(work, 0755))
+	git("init", "-q", "-b", "master")
+	write("LICENSE", "license")
+	write("go.mod", "module example.com/repo\n")
+	write("repo.go", "package repo\n")
+	write("vendor/modules.txt", "# vendored\n")
+	write("vendor/example.com/dep/dep.go", "package dep\n")
+	write("sub/go.mod", "module example.com/repo/sub\n")
+	write("sub/sub.go", "package sub\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	git("tag", "v1.0.0")
+	git("tag", "-a", "-m", "sub", "sub/v0.1.0")
+
+	date = "2018-08-02T12:00:00Z"
+	write("repo.go", "package repo // changed\n")
+	git("commit", "-q", "-am", "second")
+	git("clone", "-q", "--bare", work, bare)
+
+	repo := func(mod string) (string, string, error) {
+		switch mod {
+		case gitMod:
+			return "file://" + bare, "", nil
+		case gitMod + "/sub":
+			return "file://" + bare, "sub", nil
+		}
+		return "", "", errors.E("repo", errors.KindNotFound)
+	}
+	return repo, tmp
+}
+
+func (s *ModuleSuite) gitFetch(repo RepoFunc, mod, ver string) (*storage.Version, map[string]string, error) {
+	r := s.Require()
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git is not installed")
+	}
+	fetcher, err := NewGitFetcher("git", afero.NewOsFs(), repo)
+	r.NoError(err)
+	v, err := fetcher.Fetch(ctx, mod, ver)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer v.Zip.Close()
+	b, err := ioutil.ReadAll(v.Zip)
+	r.NoError(err)
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	r.NoError(err)
+	files := map[string]string{}
+	for _, f := range z.File {
+		rc, err := f.Open()
+		r.NoError(err)
+		content, err := ioutil.ReadAll(rc)
+		r.NoError(err)
+		files[f.Name] = string(content)
+	}
+	sum, err := HashZip(bytes.NewReader(b), int64(len(b)))
+	r.NoError(err)
+	r.Equal(sum, v.Sum)
+	return v, files, nil
+}
+
+func (s *ModuleSuite) TestGitFetcherTag() {
+	r := s.Require()
+	repo, tmp := s.gitRepoFixture()
+	defer os.RemoveAll(tmp)
+
+	v, files, err := s.gitFetch(repo, gitMod, "v1.0.0")
+	r.NoError(err)
+	r.Equal(`{"Version":"v1.0.0","Time":"2018-08-01T12:00:00Z"}`, string(v.Info))
+	r.Equal("module example.com/repo\n", string(v.Mod))
+	r.Equal(map[string]string{
+		"example.com/repo@v1.0.0/LICENSE":            "license",
+		"example.com/repo@v1.0.0/go.mod":             "module example.com/repo\n",
+		"example.com/repo@v1.0.0/repo.go":            "package repo\n",
+		"example.com/repo@v1.0.0/vendor/modules.txt": "# vendored\n",
+	}, files)
+	modSum, err := HashGoMod(v.Mod)
+	r.NoError(err)
+	r.Equal(modSum, v.GoModSum)
+}
+
+func (s *ModuleSuite) TestGitFetcherSubdirectory() {
+	r := s.Require()
+	repo, tmp := s.gitRepoFixture()
+	defer os.RemoveAll(tmp)
+
+	v, files, err := s.gitFetch(repo, gitMod+"/sub", "v0.1.0")
+	r.NoError(err)
+	r.Equal(`{"Version":"v0.1.0","Time":"2018-08-01T12:00:00Z"}`, string(v.Info))
+	r.Equal(map[string]string{
+		"example.com/repo/sub@v0.1.0/go.mod":  "module example.com/repo/sub\n",
+		"example.com/repo/sub@v0.1.0/sub.go":  "package sub\n",
+		"example.com/repo/sub@v0.1.0/LICENSE": "license",
+	}, files)
+}
+
+func (s *ModuleSuite) TestGitFetcherPseudoVersion() {
+	r := s.Require()
+	repo, tmp := s.gitRepoFixture()
+	defer os.RemoveAll(tmp)
+
+	v, _, err := s.gitFetch(repo, gitMod, "master")
+	r.NoError(err)
+	var info storage.RevInfo
+	r.NoError(json.Unmarshal(v.Info, &info))
+	r.True(strings.HasPrefix(info.Version, "v1.0.1-0.20180802120000-"), info.Version)
+
+	v, files, err := s.gitFetch(repo, gitMod, info.Version)
+	r.NoError(err)
+	r.Equal("package repo // changed\n", files["example.com/repo@"+info.Version+"/repo.go"])
+
+	rev, err := semver.PseudoRev(info.Version)
+	r.NoError(err)
+	_, _, err = s.gitFetch(repo, gitMod, "v0.0.0-20180801120000-"+rev)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
+
+func (s *ModuleSuite) TestGitFetcherNotFound() {
+	r := s.Require()
+	repo, tmp := s.gitRepoFixture()
+	defer os.RemoveAll(tmp)
+
+	for _, ver := range []string{"v1.2.3", "v2.0.0", "nobranch", "--upload-pack=true"} {
+		_, _, err := s.gitFetch(repo, gitMod, ver)
+		r.Equal(errors.KindNotFound, errors.Kind(err), ver)
+	}
+	_, _, err := s.gitFetch(repo, "example.com/other", "v1.0.0")
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
+
+func (s *ModuleSuite) TestGitLister() {
+	r := s.Require()
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git is not installed")
+	}
+	repo, tmp := s.gitRepoFixture()
+	defer os.RemoveAll(tmp)
+	// example.com/repo/untagged has no tags of its own
+	l, err := NewGitLister("git", afero.NewOsFs(), func(mod string) (string, string, error) {
+		if mod == gitMod+"/untagged" {
+			repoURL, _, err := repo(gitMod)
+			return repoURL, "untagged", err
+		}
+		return repo(mod)
+	})
+	r.NoError(err)
+
+	rev, versions, err := l.List(ctx, gitMod)
+	r.NoError(err)
+	r.Equal([]string{"v1.0.0"}, versions)
+	r.Equal("v1.0.0", rev.Version)
+	r.Equal("2018-08-01T12:00:00Z", rev.Time.Format(time.RFC3339))
+
+	rev, versions, err = l.List(ctx, gitMod+"/sub")
+	r.NoError(err)
+	r.Equal([]string{"v0.1.0"}, versions)
+	r.Equal("v0.1.0", rev.Version)
+
+	// without tags the latest version is the default branch
+	rev, versions, err = l.List(ctx, gitMod+"/untagged")
+	r.NoError(err)
+	r.Empty(versions)
+	r.True(strings.HasPrefix(rev.Version, "v0.0.0-20180802120000-"), rev.Version)
+
+	_, _, err = l.List(ctx, "example.com/other")
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
@@ -0,0 +1,118 @@
+package module
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+// GitLister lists the versions of modules straight from
+// the tags of their git repositories, without the go command
+type GitLister struct {
+	f *gitFetcher
+}
+
+// NewGitLister creates a GitLister. repo resolves module paths
+// to repositories, the same way as for NewGitFetcher, and
+// defaults to DefaultRepo.
+func NewGitLister(gitBinaryName string, fs afero.Fs, repo RepoFunc) (*GitLister, error) {
+	const op errors.Op = "module.NewGitLister"
+	if err := validGitBinary(gitBinaryName); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if repo == nil {
+		repo = DefaultRepo
+	}
+	return &GitLister{&gitFetcher{fs: fs, gitBinaryName: gitBinaryName, repo: repo}}, nil
+}
+
+// List returns the versions of mod, which are the tags of its
+// repository that are versions of its module path, in semver order.
+// Like go list -m, it also returns the latest version of mod: the
+// newest release, else the newest pre-release, else the pseudo-version
+// of the default branch. +incompatible versions are not listed.
+func (l *GitLister) List(ctx context.Context, mod string) (*storage.RevInfo, []string, error) {
+	const op errors.Op = "GitLister.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+
+	repoURL, dir, err := l.f.repo(mod)
+	if err != nil {
+		return nil, nil, errors.E(op, err, errors.M(mod))
+	}
+	tagPrefix := ""
+	if dir != "" {
+		tagPrefix = dir + "/"
+	}
+	major := pathMajor(mod)
+	out, err := (&gitRepo{gitBinaryName: l.f.gitBinaryName}).run(ctx, "ls-remote", repoURL)
+	if err != nil {
+		return nil, nil, errors.E(op, err, errors.M(mod))
+	}
+
+	versions := []string{}
+	head := ""
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		ref := fields[1]
+		if ref == "HEAD" {
+			head = fields[0]
+			continue
+		}
+		// annotated tags are listed once more with the commit they point to
+		if !strings.HasPrefix(ref, "refs/tags/"+tagPrefix) || strings.HasSuffix(ref, "^{}") {
+			continue
+		}
+		if v := strings.TrimPrefix(ref, "refs/tags/"+tagPrefix); tagMatches(v, major) {
+			versions = append(versions, v)
+		}
+	}
+	semver.Sort(versions)
+
+	latest := semver.Latest(versions)
+	if latest == "" {
+		latest = head
+	}
+	if latest == "" {
+		// an empty repository has no versions at all
+		return nil, versions, nil
+	}
+	rev, err := l.revInfo(ctx, repoURL, tagPrefix, major, latest)
+	if err != nil {
+		return nil, nil, errors.E(op, err, errors.M(mod))
+	}
+	return rev, versions, nil
+}
+
+// revInfo fetches the commit of ver into a temporary bare repository
+// and returns the version and time of the commit
+func (l *GitLister) revInfo(ctx context.Context, repoURL, tagPrefix, major, ver string) (*storage.RevInfo, error) {
+	const op errors.Op = "GitLister.revInfo"
+	root, err := afero.TempDir(l.f.fs, "", "athens-git")
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer ClearFiles(l.f.fs, root)
+	r := &gitRepo{gitBinaryName: l.f.gitBinaryName, dir: root}
+	if _, err := r.run(ctx, "init", "--bare", "-q"); err != nil {
+		return nil, errors.E(op, err)
+	}
+	commit, version, err := l.f.resolve(ctx, r, repoURL, tagPrefix, major, ver)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	secs, err := r.commitTime(ctx, commit)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &storage.RevInfo{Version: version, Time: time.Unix(secs, 0).UTC()}, nil
+}
@@ -99,6 +99,9 @@ func (g *goGetFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Ver
 	// if we close, then the caller will panic, and the alternative to make this work is
 	// that we read into memory and return an io.ReadCloser that reads out of memory
 	storageVer.Zip = &zipReadCloser{zip, g.fs, goPathRoot}
+	storageVer.Sum = m.Sum
+	storageVer.GoModSum = m.GoModSum
+	storageVer.Origin = "go mod download"
 
 	return &storageVer, nil
 }
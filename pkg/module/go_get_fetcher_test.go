@@ -1,6 +1,7 @@
 package module
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
 	"runtime"
@@ -48,6 +49,13 @@ func (s *ModuleSuite) TestGoGetFetcherFetch() {
 	r.NoError(err)
 	r.True(len(zipBytes) > 0)
 
+	sum, err := HashZip(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	r.NoError(err)
+	r.Equal(sum, ver.Sum)
+	goModSum, err := HashGoMod(ver.Mod)
+	r.NoError(err)
+	r.Equal(goModSum, ver.GoModSum)
+
 	// close the version's zip file (which also cleans up the underlying GOPATH) and expect it to fail again
 	r.NoError(ver.Zip.Close())
 }
@@ -0,0 +1,48 @@
+package module
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+type upstreamFetcher struct {
+	url      string
+	timeout  time.Duration
+	download Downloader
+}
+
+// NewUpstreamFetcher creates a fetcher which downloads modules from
+// the proxies at urls with the download protocol, so that no go binary
+// is needed. The proxies are tried in order like a chain of fetchers:
+// a proxy that does not have a module version, and answers 404 or 410,
+// falls through to the next one, but any other error fails the fetch.
+func NewUpstreamFetcher(timeout time.Duration, urls ...string) (Fetcher, error) {
+	const op errors.Op = "module.NewUpstreamFetcher"
+	if len(urls) == 0 {
+		return nil, errors.E(op, "no upstream proxies")
+	}
+	fetchers := make([]Fetcher, 0, len(urls))
+	for _, u := range urls {
+		fetchers = append(fetchers, &upstreamFetcher{url: u, timeout: timeout, download: Download})
+	}
+	if len(fetchers) == 1 {
+		return fetchers[0], nil
+	}
+	return NewChainFetcher(fetchers...), nil
+}
+
+// Fetch downloads the .info, .mod, and .zip files of a module version
+func (f *upstreamFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	const op errors.Op = "upstreamFetcher.Fetch"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	v, err := f.download(ctx, f.timeout, f.url, mod, ver)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return v, nil
+}
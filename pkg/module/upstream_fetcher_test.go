@@ -0,0 +1,83 @@
+package module
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// fakeProxy serves files by their escaped path and
+// answers 404 for everything else
+func fakeProxy(files map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := files[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(f))
+	}))
+}
+
+func gizmoFiles(zip string) map[string]string {
+	return map[string]string{
+		"/github.com/!n!y!times/gizmo/@v/v0.1.4.info": `{"Version":"v0.1.4"}`,
+		"/github.com/!n!y!times/gizmo/@v/v0.1.4.mod":  "module github.com/NYTimes/gizmo",
+		"/github.com/!n!y!times/gizmo/@v/v0.1.4.zip":  zip,
+	}
+}
+
+func (s *ModuleSuite) TestUpstreamFetcherFallsThrough() {
+	r := s.Require()
+	empty := fakeProxy(map[string]string{})
+	defer empty.Close()
+	full := fakeProxy(gizmoFiles("zip"))
+	defer full.Close()
+
+	fetcher, err := NewUpstreamFetcher(time.Minute, empty.URL, full.URL)
+	r.NoError(err)
+	ver, err := fetcher.Fetch(ctx, repoURI, version)
+	r.NoError(err)
+	defer ver.Zip.Close()
+
+	r.Equal(`{"Version":"v0.1.4"}`, string(ver.Info))
+	r.Equal("module github.com/NYTimes/gizmo", string(ver.Mod))
+	zip, err := ioutil.ReadAll(ver.Zip)
+	r.NoError(err)
+	r.Equal("zip", string(zip))
+}
+
+func (s *ModuleSuite) TestUpstreamFetcherNotFound() {
+	r := s.Require()
+	empty := fakeProxy(map[string]string{})
+	defer empty.Close()
+
+	fetcher, err := NewUpstreamFetcher(time.Minute, empty.URL, empty.URL)
+	r.NoError(err)
+	_, err = fetcher.Fetch(ctx, repoURI, version)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
+
+func (s *ModuleSuite) TestUpstreamFetcherStopsOnError() {
+	r := s.Require()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+	full := fakeProxy(gizmoFiles("zip"))
+	defer full.Close()
+
+	fetcher, err := NewUpstreamFetcher(time.Minute, broken.URL, full.URL)
+	r.NoError(err)
+	_, err = fetcher.Fetch(ctx, repoURI, version)
+	r.Error(err)
+	r.False(errors.IsNotFoundErr(err))
+}
+
+func (s *ModuleSuite) TestNewUpstreamFetcherNoURLs() {
+	_, err := NewUpstreamFetcher(time.Minute)
+	s.Require().Error(err)
+}
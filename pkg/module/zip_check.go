@@ -0,0 +1,208 @@
+package module
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// The size limits of cmd/go for module zips
+const (
+	// MaxZipFile is the maximum size of a module zip file
+	// and of the uncompressed files in it
+	MaxZipFile = 500 << 20
+	// MaxGoMod is the maximum size of a go.mod file
+	MaxGoMod = 16 << 20
+	// MaxLICENSE is the maximum size of a LICENSE file
+	MaxLICENSE = 16 << 20
+)
+
+// CheckZip checks that the module zip of mod@ver follows the rules
+// of cmd/go: all files are below the mod@ver/ prefix, have valid paths
+// that do not collide when case is folded, and stay within the size
+// limits. Violations are errors of KindBadRequest.
+func CheckZip(r io.ReaderAt, size int64, mod, ver string) error {
+	const op errors.Op = "module.CheckZip"
+	if size > MaxZipFile {
+		return errors.E(op, fmt.Sprintf("module zip is larger than %d bytes", MaxZipFile), errors.KindBadRequest)
+	}
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return errors.E(op, err, errors.KindBadRequest)
+	}
+
+	prefix := mod + "@" + ver + "/"
+	folded := make(map[string]string, len(z.File))
+	var total uint64
+	for _, f := range z.File {
+		if !strings.HasPrefix(f.Name, prefix) {
+			return errors.E(op, fmt.Sprintf("%s: path does not have prefix %q", f.Name, prefix), errors.KindBadRequest)
+		}
+		name := strings.TrimPrefix(f.Name, prefix)
+		if name == "" {
+			continue
+		}
+		isDir := strings.HasSuffix(name, "/")
+		name = strings.TrimSuffix(name, "/")
+		if path.Clean(name) != name {
+			return errors.E(op, fmt.Sprintf("%s: path is not clean", f.Name), errors.KindBadRequest)
+		}
+		if err := CheckFilePath(name); err != nil {
+			return errors.E(op, fmt.Sprintf("%s: %v", f.Name, err), errors.KindBadRequest)
+		}
+		fold := foldPath(name)
+		if other, ok := folded[fold]; ok {
+			return errors.E(op, fmt.Sprintf("%s: case-insensitive file name collision with %s", f.Name, other), errors.KindBadRequest)
+		}
+		folded[fold] = f.Name
+		if isDir {
+			continue
+		}
+
+		total += f.UncompressedSize64
+		if total > MaxZipFile {
+			return errors.E(op, fmt.Sprintf("total size of the files in the module zip is larger than %d bytes", MaxZipFile), errors.KindBadRequest)
+		}
+		if name == "go.mod" && f.UncompressedSize64 > MaxGoMod {
+			return errors.E(op, fmt.Sprintf("%s: file is larger than %d bytes", f.Name, MaxGoMod), errors.KindBadRequest)
+		}
+		if name == "LICENSE" && f.UncompressedSize64 > MaxLICENSE {
+			return errors.E(op, fmt.Sprintf("%s: file is larger than %d bytes", f.Name, MaxLICENSE), errors.KindBadRequest)
+		}
+	}
+	return nil
+}
+
+// CheckGoMod checks that gomod is not larger than MaxGoMod and declares
+// the module path mod. Violations are errors of KindBadRequest.
+func CheckGoMod(gomod []byte, mod string) error {
+	const op errors.Op = "module.CheckGoMod"
+	if len(gomod) > MaxGoMod {
+		return errors.E(op, fmt.Sprintf("go.mod is larger than %d bytes", MaxGoMod), errors.KindBadRequest)
+	}
+	declared, err := modulePath(gomod)
+	if err != nil {
+		return errors.E(op, err, errors.KindBadRequest)
+	}
+	if declared != mod {
+		return errors.E(op, fmt.Sprintf("go.mod declares module path %q instead of %q", declared, mod), errors.KindBadRequest)
+	}
+	return nil
+}
+
+// modulePath returns the path of the module directive of a go.mod file
+func modulePath(gomod []byte) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(gomod))
+	s.Buffer(nil, MaxGoMod)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+		p := strings.TrimSpace(strings.TrimSpace(line)[len("module"):])
+		if strings.HasPrefix(p, `"`) || strings.HasPrefix(p, "`") {
+			unquoted, err := strconv.Unquote(p)
+			if err != nil {
+				return "", fmt.Errorf("invalid module path %s in go.mod", p)
+			}
+			p = unquoted
+		}
+		return p, nil
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("go.mod has no module directive")
+}
+
+var badWindowsNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// CheckFilePath checks that p is a valid path of a file in a module
+// zip: a slash separated path of non-empty elements that are not made
+// up of dots only, do not end in a dot, use only letters, digits and
+// the characters !#$%&()+,-.=@[]^_{}~ and space, and are valid file
+// names on Windows.
+func CheckFilePath(p string) error {
+	if !utf8.ValidString(p) {
+		return fmt.Errorf("invalid UTF-8")
+	}
+	if p == "" {
+		return fmt.Errorf("empty string")
+	}
+	if strings.HasPrefix(p, "/") {
+		return fmt.Errorf("leading slash")
+	}
+	if strings.Contains(p, "//") {
+		return fmt.Errorf("double slash")
+	}
+	if strings.HasSuffix(p, "/") {
+		return fmt.Errorf("trailing slash")
+	}
+	for _, elem := range strings.Split(p, "/") {
+		if strings.Count(elem, ".") == len(elem) {
+			return fmt.Errorf("invalid path element %q", elem)
+		}
+		if strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("trailing dot in path element %q", elem)
+		}
+		for _, r := range elem {
+			if !fileNameOK(r) {
+				return fmt.Errorf("invalid char %q", r)
+			}
+		}
+		short := elem
+		if i := strings.Index(short, "."); i >= 0 {
+			short = short[:i]
+		}
+		for _, bad := range badWindowsNames {
+			if strings.EqualFold(bad, short) {
+				return fmt.Errorf("%q disallowed as path element component on Windows", short)
+			}
+		}
+	}
+	return nil
+}
+
+func fileNameOK(r rune) bool {
+	if r < utf8.RuneSelf {
+		const allowed = "!#$%&()+,-.=@[]^_{}~ "
+		if '0' <= r && r <= '9' || 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' {
+			return true
+		}
+		return strings.ContainsRune(allowed, r)
+	}
+	return unicode.IsLetter(r)
+}
+
+// foldPath returns p with every rune replaced by the
+// smallest rune it is equal to under case folding
+func foldPath(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		b.WriteRune(min)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package module
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+func (s *ModuleSuite) TestCheckZip() {
+	r := s.Require()
+	const prefix = "example.com/mod@v1.0.0/"
+	for _, tc := range []struct {
+		name  string
+		files []string
+		valid bool
+	}{
+		{"valid", []string{"go.mod", "mod.go", "internal/x.go", "LICENSE"}, true},
+		{"directory entry", []string{"go.mod", "internal/", "internal/x.go"}, true},
+		{"missing prefix", []string{"go.mod", "../mod.go"}, false},
+		{"other module", []string{"go.mod", "#example.com/other@v1.0.0/mod.go"}, false},
+		{"not clean", []string{"go.mod", "a/../mod.go"}, false},
+		{"double slash", []string{"go.mod", "a//mod.go"}, false},
+		{"trailing dot", []string{"go.mod", "mod."}, false},
+		{"invalid char", []string{"go.mod", "mod:go"}, false},
+		{"windows name", []string{"go.mod", "aux.go"}, false},
+		{"case collision", []string{"go.mod", "Mod.go", "mod.go"}, false},
+		{"case collision with dir", []string{"go.mod", "A/", "a"}, false},
+	} {
+		files := map[string]string{}
+		var order []string
+		for _, f := range tc.files {
+			name := prefix + f
+			if strings.HasPrefix(f, "#") {
+				name = f[1:]
+			}
+			files[name] = "content"
+			order = append(order, name)
+		}
+		z := testZip(s, files, order...)
+		err := CheckZip(bytes.NewReader(z), int64(len(z)), "example.com/mod", "v1.0.0")
+		if tc.valid {
+			r.NoError(err, tc.name)
+		} else {
+			r.Equal(errors.KindBadRequest, errors.Kind(err), "%s: %v", tc.name, err)
+		}
+	}
+}
+
+func (s *ModuleSuite) TestCheckZipSize() {
+	r := s.Require()
+	files := map[string]string{"example.com/mod@v1.0.0/go.mod": strings.Repeat(" ", MaxGoMod+1)}
+	z := testZip(s, files, "example.com/mod@v1.0.0/go.mod")
+	err := CheckZip(bytes.NewReader(z), int64(len(z)), "example.com/mod", "v1.0.0")
+	r.Equal(errors.KindBadRequest, errors.Kind(err))
+
+	err = CheckZip(bytes.NewReader(z), MaxZipFile+1, "example.com/mod", "v1.0.0")
+	r.Equal(errors.KindBadRequest, errors.Kind(err))
+}
+
+func (s *ModuleSuite) TestCheckGoMod() {
+	r := s.Require()
+	for gomod, valid := range map[string]bool{
+		"module example.com/mod\n":                                 true,
+		"// comment\nmodule example.com/mod // comment\n":          true,
+		"module \"example.com/mod\"\n\nrequire other.com/x v1.0.0": true,
+		"module example.com/other\n":                               false,
+		"module example.com/mod/v2\n":                              false,
+		"require example.com/mod v1.0.0\n":                         false,
+		"":                                                         false,
+	} {
+		err := CheckGoMod([]byte(gomod), "example.com/mod")
+		if valid {
+			r.NoError(err, gomod)
+		} else {
+			r.Equal(errors.KindBadRequest, errors.Kind(err), gomod)
+		}
+	}
+	err := CheckGoMod([]byte("module example.com/mod\n"+strings.Repeat(" ", MaxGoMod)), "example.com/mod")
+	r.Equal(errors.KindBadRequest, errors.Kind(err))
+}
@@ -0,0 +1,51 @@
+package paths
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// EncodePath returns the safe encoding of the given module path,
+// in which every upper case letter is replaced by an exclamation
+// mark followed by the lower case letter. It fails if the path
+// contains characters that can not be encoded.
+func EncodePath(path string) (encoding string, err error) {
+	const op errors.Op = "paths.EncodePath"
+	encoding, ok := encodeString(path)
+	if !ok {
+		return "", errors.E(op, fmt.Sprintf("invalid module path %q", path))
+	}
+
+	return encoding, nil
+}
+
+// Ripped from cmd/go
+func encodeString(s string) (string, bool) {
+	haveUpper := false
+	for _, r := range s {
+		if r == '!' || r >= utf8.RuneSelf {
+			// This should be disallowed by CheckPath, but diagnose anyway.
+			// The correctness of the encoding loop below depends on it.
+			return "", false
+		}
+		if 'A' <= r && r <= 'Z' {
+			haveUpper = true
+		}
+	}
+
+	if !haveUpper {
+		return s, true
+	}
+
+	var buf []byte
+	for _, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			buf = append(buf, '!', byte(r+'a'-'A'))
+		} else {
+			buf = append(buf, byte(r))
+		}
+	}
+	return string(buf), true
+}
@@ -0,0 +1,76 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var pseudoRE = regexp.MustCompile(`^v[0-9]+\.(0\.0-|[0-9]+\.[0-9]+-([^+]*\.)?0\.)[0-9]{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+const pseudoTimeFormat = "20060102150405"
+
+// IsPseudo reports whether v is a pseudo-version, in any of the forms
+// vX.0.0-yyyymmddhhmmss-abcdefabcdef, vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef
+// and vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef, with optional build metadata.
+func IsPseudo(v string) bool {
+	return strings.Count(v, "-") >= 2 && IsValid(v) && pseudoRE.MatchString(v)
+}
+
+// PseudoVersion returns the pseudo-version of the commit rev made at t,
+// in one of the three forms of the go command: vX.0.0-yyyymmddhhmmss-rev
+// when older is empty, vX.Y.Z-pre.0.yyyymmddhhmmss-rev when older is the
+// pre-release vX.Y.Z-pre, and vX.Y.(Z+1)-0.yyyymmddhhmmss-rev when older
+// is the release vX.Y.Z. Build metadata of older, such as +incompatible,
+// is kept. major is the major version for the first form, such as "v2",
+// and defaults to "v0".
+func PseudoVersion(major, older string, t time.Time, rev string) string {
+	if major == "" {
+		major = "v0"
+	}
+	segment := t.UTC().Format(pseudoTimeFormat) + "-" + rev
+	ver, ok := Parse(older)
+	if !ok {
+		return major + ".0.0-" + segment
+	}
+	build := ""
+	if ver.Build != "" {
+		build = "+" + ver.Build
+	}
+	if ver.Prerelease != "" {
+		return fmt.Sprintf("v%s.%s.%s-%s.0.%s%s", ver.Major, ver.Minor, ver.Patch, ver.Prerelease, segment, build)
+	}
+	patch, _ := strconv.Atoi(ver.Patch)
+	return fmt.Sprintf("v%s.%s.%d-0.%s%s", ver.Major, ver.Minor, patch+1, segment, build)
+}
+
+// PseudoTime returns the commit time encoded in the pseudo-version v
+func PseudoTime(v string) (time.Time, error) {
+	ts, _, err := splitPseudo(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(pseudoTimeFormat, ts)
+}
+
+// PseudoRev returns the abbreviated commit hash in the pseudo-version v
+func PseudoRev(v string) (string, error) {
+	_, rev, err := splitPseudo(v)
+	return rev, err
+}
+
+// splitPseudo returns the timestamp and commit hash of the pseudo-version v
+func splitPseudo(v string) (ts, rev string, err error) {
+	if !IsPseudo(v) {
+		return "", "", fmt.Errorf("%q is not a pseudo-version", v)
+	}
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+	j := strings.LastIndex(v, "-")
+	v, rev = v[:j], v[j+1:]
+	i := strings.LastIndexAny(v, "-.")
+	return v[i+1:], rev, nil
+}
@@ -0,0 +1,247 @@
+// Package semver implements the versions of Go modules: semantic
+// versions with a leading v, such as v1.2.3-pre+build, including
+// +incompatible versions and the three forms of pseudo-versions.
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Version is a parsed semantic version
+type Version struct {
+	// Major, Minor and Patch are decimal numbers without leading zeros
+	Major, Minor, Patch string
+	// Prerelease is the pre-release without the leading "-", if any
+	Prerelease string
+	// Build is the build metadata without the leading "+", if any
+	Build string
+}
+
+// Parse parses the semantic version v, which must have a leading v
+// and all three version numbers. ok is false if v is invalid.
+func Parse(v string) (ver Version, ok bool) {
+	if !strings.HasPrefix(v, "v") {
+		return Version{}, false
+	}
+	v = v[1:]
+	if i := strings.Index(v, "+"); i >= 0 {
+		v, ver.Build = v[:i], v[i+1:]
+		if !validIdents(ver.Build, false) {
+			return Version{}, false
+		}
+	}
+	if i := strings.Index(v, "-"); i >= 0 {
+		v, ver.Prerelease = v[:i], v[i+1:]
+		if !validIdents(ver.Prerelease, true) {
+			return Version{}, false
+		}
+	}
+	nums := strings.Split(v, ".")
+	if len(nums) != 3 {
+		return Version{}, false
+	}
+	for _, n := range nums {
+		if !isNum(n) || len(n) > 1 && n[0] == '0' {
+			return Version{}, false
+		}
+	}
+	ver.Major, ver.Minor, ver.Patch = nums[0], nums[1], nums[2]
+	return ver, true
+}
+
+// validIdents reports whether s is a dot separated list of non-empty
+// identifiers of ASCII letters, digits and hyphens. Numeric identifiers
+// of a pre-release must not have leading zeros.
+func validIdents(s string, prerelease bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, r := range id {
+			if !('0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+		if prerelease && isNum(id) && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the version with its leading v
+func (v Version) String() string {
+	s := "v" + v.Major + "." + v.Minor + "." + v.Patch
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsIncompatible reports whether v is a +incompatible version, a version
+// from v2 on of a module that does not use a major version suffix
+func (v Version) IsIncompatible() bool {
+	return v.Build == "incompatible"
+}
+
+// IsValid reports whether v is a valid semantic version
+func IsValid(v string) bool {
+	_, ok := Parse(v)
+	return ok
+}
+
+// Major returns the major version of v, such as "v2",
+// or "" if v is not a valid semantic version
+func Major(v string) string {
+	ver, ok := Parse(v)
+	if !ok {
+		return ""
+	}
+	return "v" + ver.Major
+}
+
+// Prerelease returns the pre-release of v without the leading "-",
+// or "" if v is not a valid semantic version or not a pre-release
+func Prerelease(v string) string {
+	ver, _ := Parse(v)
+	return ver.Prerelease
+}
+
+// Compare returns -1, 0 or 1 as v orders before, the same as, or after w
+// in semantic version precedence. Build metadata such as +incompatible
+// does not take part in it. Invalid versions order before valid ones and
+// are all the same.
+func Compare(v, w string) int {
+	vv, vOK := Parse(v)
+	wv, wOK := Parse(w)
+	switch {
+	case !vOK && !wOK:
+		return 0
+	case !vOK:
+		return -1
+	case !wOK:
+		return 1
+	}
+	if c := compareNum(vv.Major, wv.Major); c != 0 {
+		return c
+	}
+	if c := compareNum(vv.Minor, wv.Minor); c != 0 {
+		return c
+	}
+	if c := compareNum(vv.Patch, wv.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(vv.Prerelease, wv.Prerelease)
+}
+
+// Sort sorts versions in ascending order of precedence. Versions of the
+// same precedence, and invalid versions, which come first, sort lexically.
+func Sort(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		if c := Compare(versions[i], versions[j]); c != 0 {
+			return c < 0
+		}
+		return versions[i] < versions[j]
+	})
+}
+
+// Latest returns the version the go command picks as the latest of versions:
+// the newest release, else the newest pre-release, else the newest
+// pseudo-version. It returns "" if there is no valid version.
+func Latest(versions []string) string {
+	var latest string
+	latestRank := 0
+	for _, v := range versions {
+		ver, ok := Parse(v)
+		if !ok {
+			continue
+		}
+		rank := 3
+		switch {
+		case IsPseudo(v):
+			rank = 1
+		case ver.Prerelease != "":
+			rank = 2
+		}
+		if rank > latestRank || rank == latestRank && Compare(v, latest) > 0 {
+			latest, latestRank = v, rank
+		}
+	}
+	return latest
+}
+
+// comparePrerelease compares pre-releases, where
+// no pre-release orders after any pre-release
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := compareIdent(aIDs[i], bIDs[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(aIDs) < len(bIDs):
+		return -1
+	case len(aIDs) > len(bIDs):
+		return 1
+	}
+	return 0
+}
+
+// compareIdent compares pre-release identifiers: numeric ones
+// numerically and before alphanumeric ones, which compare in ASCII order
+func compareIdent(a, b string) int {
+	aNum, bNum := isNum(a), isNum(b)
+	switch {
+	case aNum && bNum:
+		return compareNum(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareNum compares decimal numbers without leading zeros
+func compareNum(a, b string) int {
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isNum(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
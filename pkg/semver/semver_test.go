@@ -0,0 +1,100 @@
+package semver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for v, want := range map[string]Version{
+		"v1.2.3":                 {Major: "1", Minor: "2", Patch: "3"},
+		"v1.2.3-pre.1":           {Major: "1", Minor: "2", Patch: "3", Prerelease: "pre.1"},
+		"v2.0.0+incompatible":    {Major: "2", Minor: "0", Patch: "0", Build: "incompatible"},
+		"v10.20.30-rc-1+build.5": {Major: "10", Minor: "20", Patch: "30", Prerelease: "rc-1", Build: "build.5"},
+	} {
+		got, ok := Parse(v)
+		require.True(t, ok, v)
+		require.Equal(t, want, got, v)
+		require.Equal(t, v, got.String())
+	}
+	for _, v := range []string{"", "1.2.3", "v1", "v1.2", "v1.2.3.4", "v01.2.3", "v1.2.3-", "v1.2.3-01", "v1.2.3-a..b", "v1.2.3+", "v1.2.3-a_b", "master"} {
+		_, ok := Parse(v)
+		require.False(t, ok, v)
+	}
+	v, _ := Parse("v2.0.0+incompatible")
+	require.True(t, v.IsIncompatible())
+	require.Equal(t, "v2", Major("v2.0.0+incompatible"))
+	require.Equal(t, "", Major("master"))
+	require.Equal(t, "rc.1", Prerelease("v1.0.0-rc.1"))
+}
+
+func TestSort(t *testing.T) {
+	versions := []string{
+		"v1.10.0", "v1.2.0", "master", "v1.2.0-rc.10", "v1.2.0-rc.2", "v1.2.0-beta",
+		"v1.2.0-alpha.1", "v1.2.0-alpha", "v2.0.0+incompatible", "v0.0.0-20180801120000-0123456789ab",
+		"v1.2.1-0.20180801120000-0123456789ab",
+	}
+	Sort(versions)
+	require.Equal(t, []string{
+		"master",
+		"v0.0.0-20180801120000-0123456789ab",
+		"v1.2.0-alpha",
+		"v1.2.0-alpha.1",
+		"v1.2.0-beta",
+		"v1.2.0-rc.2",
+		"v1.2.0-rc.10",
+		"v1.2.0",
+		"v1.2.1-0.20180801120000-0123456789ab",
+		"v1.10.0",
+		"v2.0.0+incompatible",
+	}, versions)
+}
+
+func TestLatest(t *testing.T) {
+	for _, tc := range []struct {
+		versions []string
+		latest   string
+	}{
+		{nil, ""},
+		{[]string{"master", "v1"}, ""},
+		{[]string{"v1.0.0", "v1.10.0", "v1.9.0"}, "v1.10.0"},
+		{[]string{"v2.0.0-rc.1", "v1.0.0"}, "v1.0.0"},
+		{[]string{"v1.0.1-0.20180801120000-0123456789ab", "v1.0.0"}, "v1.0.0"},
+		{[]string{"v1.0.1-0.20180801120000-0123456789ab", "v1.0.0-rc.1"}, "v1.0.0-rc.1"},
+		{[]string{"v0.0.0-20180801120000-0123456789ab", "v0.0.0-20180802120000-0123456789ab"}, "v0.0.0-20180802120000-0123456789ab"},
+		{[]string{"v2.0.0+incompatible", "v1.5.0"}, "v2.0.0+incompatible"},
+	} {
+		require.Equal(t, tc.latest, Latest(tc.versions), "%v", tc.versions)
+	}
+}
+
+func TestPseudo(t *testing.T) {
+	tm := time.Date(2018, 8, 1, 12, 0, 0, 0, time.UTC)
+	rev := "0123456789ab"
+	for _, tc := range []struct {
+		major, older, pseudo string
+	}{
+		{"", "", "v0.0.0-20180801120000-0123456789ab"},
+		{"v2", "", "v2.0.0-20180801120000-0123456789ab"},
+		{"", "v1.2.3", "v1.2.4-0.20180801120000-0123456789ab"},
+		{"", "v1.2.3-pre", "v1.2.3-pre.0.20180801120000-0123456789ab"},
+		{"", "v2.0.0+incompatible", "v2.0.1-0.20180801120000-0123456789ab+incompatible"},
+	} {
+		v := PseudoVersion(tc.major, tc.older, tm, rev)
+		require.Equal(t, tc.pseudo, v)
+		require.True(t, IsPseudo(v), v)
+		got, err := PseudoTime(v)
+		require.NoError(t, err)
+		require.Equal(t, tm, got)
+		gotRev, err := PseudoRev(v)
+		require.NoError(t, err)
+		require.Equal(t, rev, gotRev)
+	}
+	for _, v := range []string{"v1.2.3", "v1.2.3-pre", "v0.0.0-pre", "v1.2.3-0.2018-0123456789ab", "v0.0.0-20180801120000"} {
+		require.False(t, IsPseudo(v), v)
+		_, err := PseudoRev(v)
+		require.Error(t, err, v)
+	}
+}
@@ -2,6 +2,12 @@ package stash
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/gomods/athens/pkg/errors"
@@ -51,14 +57,75 @@ func (s *stasher) Stash(ctx context.Context, mod, ver string) error {
 	if err != nil {
 		return errors.E(op, err)
 	}
+	fetchedAt := time.Now().UTC()
 	defer v.Zip.Close()
-	err = s.s.Save(ctx, mod, ver, v.Mod, v.Zip, v.Info)
+	zip, err := validate(v, mod, ver)
+	if err != nil {
+		return errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	defer zip.Close()
+	sum, err := checksum(v, zip)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	// a checksum stored without its version is kept by a refetch,
+	// so that upstream can not replace what was stored first
+	stored, err := s.s.Checksum(ctx, mod, ver)
+	if err == nil && (stored.Sum != sum.Sum || stored.GoModSum != sum.GoModSum) {
+		if exists, err := s.s.Exists(ctx, mod, ver); err == nil && exists {
+			// another process stashed the version meanwhile
+			return nil
+		}
+		err := fmt.Errorf("upstream content changed: stored checksum %s %s, upstream %s %s", stored.Sum, stored.GoModSum, sum.Sum, sum.GoModSum)
+		return errors.E(op, err, errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return errors.E(op, err)
+	}
+	if _, err := zip.Seek(0, io.SeekStart); err != nil {
+		return errors.E(op, err)
+	}
+	zr := &countingReader{r: zip}
+	err = s.s.Save(ctx, mod, ver, v.Mod, zr, v.Info)
+	if errors.Kind(err) == errors.KindAlreadyExists {
+		// another process stashed the same version meanwhile,
+		// its checksum describes what it saved, not this fetch
+		return nil
+	}
 	if err != nil {
 		return errors.E(op, err)
 	}
+	// the checksum goes only after the version it describes is saved.
+	// Until then the version is served unverified, never with the
+	// checksum of another fetch.
+	err = s.s.SaveChecksum(ctx, mod, ver, sum)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	meta := storage.Metadata{
+		ZipSize:   zr.n,
+		FetchedAt: fetchedAt,
+		Origin:    v.Origin,
+		Checksum:  sum,
+	}
+	if err := s.s.SaveMetadata(ctx, mod, ver, meta); err != nil {
+		return errors.E(op, err)
+	}
 	return nil
 }
 
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 func (s *stasher) fetchModule(ctx context.Context, mod, ver string) (*storage.Version, error) {
 	const op errors.Op = "stasher.fetchModule"
 	v, err := s.f.Fetch(ctx, mod, ver)
@@ -68,3 +135,80 @@ func (s *stasher) fetchModule(ctx context.Context, mod, ver string) (*storage.Ve
 
 	return v, nil
 }
+
+// validate spools the zip of v to a temporary file and checks
+// that v is a valid module version of mod@ver, so that invalid
+// versions are rejected before anything is saved.
+func validate(v *storage.Version, mod, ver string) (*spooledZip, error) {
+	const op errors.Op = "stash.validate"
+	zip, err := spool(v.Zip)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := module.CheckZip(zip.File, zip.size, mod, ver); err != nil {
+		zip.Close()
+		return nil, errors.E(op, err)
+	}
+	if err := module.CheckGoMod(v.Mod, mod); err != nil {
+		zip.Close()
+		return nil, errors.E(op, err)
+	}
+	return zip, nil
+}
+
+// spooledZip is a zip in a temporary file,
+// which is removed when it is closed
+type spooledZip struct {
+	*os.File
+	size int64
+	// hash is the hex encoded SHA-256 of the zip
+	hash string
+}
+
+// spool copies zip to a temporary file and hashes it on the way.
+// Only up to one byte more than module.MaxZipFile is copied, which
+// is enough for CheckZip to reject the zip as too large.
+func spool(zip io.Reader) (*spooledZip, error) {
+	const op errors.Op = "stash.spool"
+	f, err := ioutil.TempFile("", "athens-stash")
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	s := &spooledZip{File: f}
+	h := sha256.New()
+	s.size, err = io.Copy(io.MultiWriter(f, h), io.LimitReader(zip, module.MaxZipFile+1))
+	if err != nil {
+		s.Close()
+		return nil, errors.E(op, err)
+	}
+	s.hash = hex.EncodeToString(h.Sum(nil))
+	return s, nil
+}
+
+func (s *spooledZip) Close() error {
+	err := s.File.Close()
+	os.Remove(s.File.Name())
+	return err
+}
+
+// checksum returns the go.sum hashes of v and
+// computes the ones the fetcher did not provide, along
+// with the SHA-256 that reads are verified with.
+func checksum(v *storage.Version, zip *spooledZip) (storage.Checksum, error) {
+	const op errors.Op = "stash.checksum"
+	sum := storage.Checksum{Sum: v.Sum, GoModSum: v.GoModSum, ZipHash: zip.hash}
+	var err error
+	if sum.GoModSum == "" {
+		sum.GoModSum, err = module.HashGoMod(v.Mod)
+		if err != nil {
+			return sum, errors.E(op, err)
+		}
+	}
+	if sum.Sum == "" {
+		sum.Sum, err = module.HashZip(zip.File, zip.size)
+		if err != nil {
+			return sum, errors.E(op, err)
+		}
+	}
+	return sum, nil
+}
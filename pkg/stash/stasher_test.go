@@ -0,0 +1,94 @@
+package stash
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+type testFetcher struct {
+	mod   []byte
+	files []string
+}
+
+func (f *testFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, name := range f.files {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		w.Write([]byte("package mod\n"))
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return &storage.Version{
+		Mod:  f.mod,
+		Info: []byte(`{"Version":"` + ver + `"}`),
+		Zip:  ioutil.NopCloser(buf),
+	}, nil
+}
+
+func TestStashValidates(t *testing.T) {
+	const mod, ver = "example.com/mod", "v1.0.0"
+	for _, tc := range []struct {
+		name  string
+		f     *testFetcher
+		valid bool
+	}{
+		{"valid", &testFetcher{[]byte("module example.com/mod\n"), []string{"example.com/mod@v1.0.0/mod.go"}}, true},
+		{"zip without prefix", &testFetcher{[]byte("module example.com/mod\n"), []string{"mod.go"}}, false},
+		{"zip of other version", &testFetcher{[]byte("module example.com/mod\n"), []string{"example.com/mod@v1.0.1/mod.go"}}, false},
+		{"case collision", &testFetcher{[]byte("module example.com/mod\n"), []string{"example.com/mod@v1.0.0/mod.go", "example.com/mod@v1.0.0/MOD.go"}}, false},
+		{"go.mod of other module", &testFetcher{[]byte("module example.com/other\n"), []string{"example.com/mod@v1.0.0/mod.go"}}, false},
+	} {
+		memFs := afero.NewMemMapFs()
+		require.NoError(t, memFs.MkdirAll("/athens", 0755))
+		s, err := fs.NewStorage("/athens", memFs)
+		require.NoError(t, err)
+		err = New(tc.f, s).Stash(context.Background(), mod, ver)
+		exists, existsErr := s.Exists(context.Background(), mod, ver)
+		require.NoError(t, existsErr)
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+			require.True(t, exists, tc.name)
+			continue
+		}
+		require.Equal(t, errors.KindBadRequest, errors.Kind(err), "%s: %v", tc.name, err)
+		require.False(t, exists, tc.name)
+		_, err = s.Checksum(context.Background(), mod, ver)
+		require.True(t, errors.IsNotFoundErr(err), tc.name)
+	}
+}
+
+func TestStashKeepsChecksumOfExistingVersion(t *testing.T) {
+	const mod, ver = "example.com/mod", "v1.0.0"
+	ctx := context.Background()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	winner := &testFetcher{[]byte("module example.com/mod\n"), []string{"example.com/mod@v1.0.0/mod.go"}}
+	require.NoError(t, New(winner, s).Stash(ctx, mod, ver))
+	sum, err := s.Checksum(ctx, mod, ver)
+	require.NoError(t, err)
+	require.NotEmpty(t, sum.ZipHash)
+
+	// a concurrent stash of other content loses to the saved version
+	// and must not replace the checksum of what was saved
+	loser := &testFetcher{[]byte("module example.com/mod\n"), []string{"example.com/mod@v1.0.0/other.go"}}
+	require.NoError(t, New(loser, s).Stash(ctx, mod, ver))
+	stored, err := s.Checksum(ctx, mod, ver)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+}
@@ -0,0 +1,198 @@
+package azurecdn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	mod  = []byte{1, 2, 3}
+	zip  = []byte{4, 5, 6}
+	info = []byte{7, 8, 9}
+)
+
+type AzureTests struct {
+	suite.Suite
+	server  *blobServerMock
+	storage *Storage
+}
+
+func (a *AzureTests) SetupSuite() {
+	const account, container = "devstoreaccount1", "gomods"
+	a.server = newBlobServerMock(account, container)
+	cl := newBlobStoreClient(a.server.accountURL(), account, mockAccountKey, container)
+	storage, err := newWithClient(account, cl, &config.CDNConfig{TimeoutConf: config.TimeoutConf{Timeout: 300}})
+	a.Require().NoError(err)
+	a.storage = storage
+}
+
+func (a *AzureTests) TearDownSuite() {
+	a.server.Close()
+}
+
+func (a *AzureTests) SetupTest() {
+	a.server.clear()
+}
+
+func TestAzureStorage(t *testing.T) {
+	suite.Run(t, new(AzureTests))
+}
+
+func (a *AzureTests) TestSaveGetListExistsRoundTrip() {
+	r := a.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	r.NoError(a.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	modBts, err := a.storage.GoMod(ctx, module, version)
+	r.NoError(err)
+	r.Equal(mod, modBts)
+
+	infoBts, err := a.storage.Info(ctx, module, version)
+	r.NoError(err)
+	r.Equal(info, infoBts)
+
+	ziprc, err := a.storage.Zip(ctx, module, version)
+	r.NoError(err)
+	gotZip, err := ioutil.ReadAll(ziprc)
+	r.NoError(ziprc.Close())
+	r.NoError(err)
+	r.Equal(zip, gotZip)
+
+	versions, err := a.storage.List(ctx, module)
+	r.NoError(err)
+	r.Equal([]string{version}, versions)
+
+	exists, err := a.storage.Exists(ctx, module, version)
+	r.NoError(err)
+	r.True(exists)
+
+	err = a.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info)
+	r.Equal(errors.KindAlreadyExists, errors.Kind(err))
+}
+
+func (a *AzureTests) TestListPages() {
+	r := a.Require()
+	ctx := context.Background()
+	module := "github.com/gomods/athens"
+	a.server.pageSize = 2
+	defer func() { a.server.pageSize = 5000 }()
+
+	expected := []string{}
+	for i := 0; i < 5; i++ {
+		version := fmt.Sprintf("v1.0.%d", i)
+		expected = append(expected, version)
+		r.NoError(a.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+	}
+
+	versions, err := a.storage.List(ctx, module)
+	r.NoError(err)
+	r.Equal(expected, versions)
+}
+
+func (a *AzureTests) TestCatalogSkipsToToken() {
+	r := a.Require()
+	ctx := context.Background()
+	a.server.pageSize = 4
+	defer func() { a.server.pageSize = 5000 }()
+	// in the order of the catalog, which differs from the order of the names
+	expected := []paths.AllPathParams{
+		{Module: "example.com/a", Version: "v1.0.0"},
+		{Module: "example.com/a", Version: "v1.0.0-pre"},
+		{Module: "example.com/a-b", Version: "v1.0.0"},
+		{Module: "example.com/a/0x", Version: "v1.0.0"},
+		{Module: "example.com/a/b", Version: "v1.0.0"},
+	}
+	for i := 0; i < 50; i++ {
+		expected = append(expected, paths.AllPathParams{Module: fmt.Sprintf("example.com/z%02d", i), Version: "v1.0.0"})
+	}
+	for _, p := range expected {
+		r.NoError(a.storage.Save(ctx, p.Module, p.Version, mod, bytes.NewReader(zip), info))
+	}
+
+	for _, pageSize := range []int{1, 3, 100} {
+		var all []paths.AllPathParams
+		token := ""
+		for {
+			page, next, err := a.storage.Catalog(ctx, token, pageSize)
+			r.NoError(err)
+			all = append(all, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		r.Equal(expected, all, "page size %d", pageSize)
+	}
+
+	// a page after a token lists the directories
+	// that hold it, not all of the blobs before it
+	a.server.lock.Lock()
+	a.server.listed = 0
+	a.server.lock.Unlock()
+	page, _, err := a.storage.Catalog(ctx, "example.com/z10@v1.0.0", 2)
+	r.NoError(err)
+	r.Equal(expected[16:18], page)
+	a.server.lock.Lock()
+	defer a.server.lock.Unlock()
+	r.True(a.server.listed < 80, "listed %d names", a.server.listed)
+}
+
+func (a *AzureTests) TestDelete() {
+	r := a.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	r.NoError(a.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+	r.NoError(a.storage.Delete(ctx, module, version))
+
+	exists, err := a.storage.Exists(ctx, module, version)
+	r.NoError(err)
+	r.False(exists)
+	r.Empty(a.server.blobs)
+
+	err = a.storage.Delete(ctx, module, version)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
+
+func (a *AzureTests) TestNotFounds() {
+	r := a.Require()
+	ctx := context.Background()
+
+	_, err := a.storage.Info(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+	_, err = a.storage.GoMod(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+	_, err = a.storage.Zip(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+
+	list, err := a.storage.List(ctx, "nothing/to/see/here")
+	r.NoError(err)
+	r.Equal(0, len(list))
+}
+
+func (a *AzureTests) TestLargeZip() {
+	r := a.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+	// larger than a single upload buffer, forcing a staged block upload
+	largeZip := bytes.Repeat([]byte("athens"), 1024*1024)
+
+	r.NoError(a.storage.Save(ctx, module, version, mod, bytes.NewReader(largeZip), info))
+
+	ziprc, err := a.storage.Zip(ctx, module, version)
+	r.NoError(err)
+	defer ziprc.Close()
+	gotZip, err := ioutil.ReadAll(ziprc)
+	r.NoError(err)
+	r.Equal(largeZip, gotZip)
+}
@@ -0,0 +1,238 @@
+package azurecdn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// mockAccountKey is the well known key of the Azurite storage emulator
+const mockAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+// blobServerMock is an in-process, Azurite-like stand-in for the
+// Azure Blob REST API. It only understands the subset of the API
+// used by azureBlobStoreClient and serves a single account and container.
+type blobServerMock struct {
+	*httptest.Server
+	account   string
+	container string
+	// pageSize is the number of blobs returned
+	// by a list request that sets no maxresults
+	pageSize int
+
+	lock   sync.Mutex
+	blobs  map[string][]byte
+	blocks map[string][]byte
+	// listed counts the names and prefixes that list requests returned
+	listed int
+}
+
+func newBlobServerMock(account, container string) *blobServerMock {
+	m := &blobServerMock{
+		account:   account,
+		container: container,
+		pageSize:  5000,
+		blobs:     make(map[string][]byte),
+		blocks:    make(map[string][]byte),
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	return m
+}
+
+// accountURL returns the path-style account URL, as used by Azurite
+func (m *blobServerMock) accountURL() *url.URL {
+	u, _ := url.Parse(m.URL + "/" + m.account)
+	return u
+}
+
+func (m *blobServerMock) clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.blobs = make(map[string][]byte)
+	m.blocks = make(map[string][]byte)
+}
+
+func (m *blobServerMock) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// shared access signatures are accepted, but not verified
+	if r.Header.Get("Authorization") == "" && r.URL.Query().Get("sig") == "" {
+		writeBlobError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+	containerPath := "/" + m.account + "/" + m.container
+	if !strings.HasPrefix(r.URL.Path, containerPath) {
+		writeBlobError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, containerPath), "/")
+	q := r.URL.Query()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if name == "" {
+		if r.Method == http.MethodGet && q.Get("restype") == "container" && q.Get("comp") == "list" {
+			m.list(w, q)
+			return
+		}
+		writeBlobError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		m.put(w, r, name)
+	case http.MethodGet, http.MethodHead:
+		data, ok := m.blobs[name]
+		if !ok {
+			writeBlobError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("x-ms-blob-type", "BlockBlob")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		if _, ok := m.blobs[name]; !ok {
+			writeBlobError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(m.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeBlobError(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+func (m *blobServerMock) put(w http.ResponseWriter, r *http.Request, name string) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeBlobError(w, http.StatusBadRequest, "InvalidInput")
+		return
+	}
+	q := r.URL.Query()
+	switch q.Get("comp") {
+	case "":
+		m.blobs[name] = body
+	case "block":
+		m.blocks[name+"#"+q.Get("blockid")] = body
+	case "blocklist":
+		var list struct {
+			Committed   []string `xml:"Committed"`
+			Uncommitted []string `xml:"Uncommitted"`
+			Latest      []string `xml:"Latest"`
+		}
+		if err := xml.Unmarshal(body, &list); err != nil {
+			writeBlobError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+		data := []byte{}
+		for _, id := range append(list.Uncommitted, list.Latest...) {
+			block, ok := m.blocks[name+"#"+id]
+			if !ok {
+				writeBlobError(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			data = append(data, block...)
+		}
+		for k := range m.blocks {
+			if strings.HasPrefix(k, name+"#") {
+				delete(m.blocks, k)
+			}
+		}
+		m.blobs[name] = data
+	default:
+		writeBlobError(w, http.StatusBadRequest, "InvalidQueryParameterValue")
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+type mockBlob struct {
+	Name string `xml:"Name"`
+}
+
+type mockEnumerationResults struct {
+	XMLName       xml.Name   `xml:"EnumerationResults"`
+	ContainerName string     `xml:"ContainerName,attr"`
+	Prefix        string     `xml:"Prefix"`
+	Marker        string     `xml:"Marker"`
+	MaxResults    int        `xml:"MaxResults,omitempty"`
+	Delimiter     string     `xml:"Delimiter,omitempty"`
+	BlobPrefixes  []mockBlob `xml:"Blobs>BlobPrefix"`
+	Blobs         []mockBlob `xml:"Blobs>Blob"`
+	NextMarker    string     `xml:"NextMarker"`
+}
+
+func (m *blobServerMock) list(w http.ResponseWriter, q url.Values) {
+	prefix, marker, delimiter := q.Get("prefix"), q.Get("marker"), q.Get("delimiter")
+	maxResults := m.pageSize
+	if mr := q.Get("maxresults"); mr != "" {
+		n, err := strconv.Atoi(mr)
+		if err != nil || n <= 0 {
+			writeBlobError(w, http.StatusBadRequest, "InvalidQueryParameterValue")
+			return
+		}
+		maxResults = n
+	}
+
+	// with a delimiter, the names below a prefix are rolled up into it
+	names := []string{}
+	isPrefix := map[string]bool{}
+	for name := range m.blobs {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if i := strings.Index(name[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			name = name[:len(prefix)+i+len(delimiter)]
+			if isPrefix[name] {
+				continue
+			}
+			isPrefix[name] = true
+		}
+		if name >= marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	res := mockEnumerationResults{
+		ContainerName: m.container,
+		Prefix:        prefix,
+		Marker:        marker,
+		MaxResults:    maxResults,
+		Delimiter:     delimiter,
+		Blobs:         []mockBlob{},
+	}
+	if len(names) > maxResults {
+		res.NextMarker = names[maxResults]
+		names = names[:maxResults]
+	}
+	m.listed += len(names)
+	for _, name := range names {
+		if isPrefix[name] {
+			res.BlobPrefixes = append(res.BlobPrefixes, mockBlob{Name: name})
+		} else {
+			res.Blobs = append(res.Blobs, mockBlob{Name: name})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, xml.Header)
+	xml.NewEncoder(w).Encode(res)
+}
+
+func writeBlobError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "%s<Error><Code>%s</Code><Message>%s</Message></Error>", xml.Header, code, http.StatusText(status))
+}
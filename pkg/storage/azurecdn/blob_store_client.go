@@ -3,7 +3,9 @@ package azurecdn
 import (
 	"context"
 	"io"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Azure/azure-storage-blob-go/2017-07-29/azblob"
 	"github.com/gomods/athens/pkg/errors"
@@ -11,7 +13,9 @@ import (
 )
 
 type azureBlobStoreClient struct {
-	containerURL *azblob.ContainerURL
+	containerURL  *azblob.ContainerURL
+	containerName string
+	cred          *azblob.SharedKeyCredential
 }
 
 func newBlobStoreClient(accountURL *url.URL, accountName, accountKey, containerName string) *azureBlobStoreClient {
@@ -23,7 +27,7 @@ func newBlobStoreClient(accountURL *url.URL, accountName, accountKey, containerN
 	//
 	// This container must exist
 	containerURL := serviceURL.NewContainerURL(containerName)
-	cl := &azureBlobStoreClient{containerURL: &containerURL}
+	cl := &azureBlobStoreClient{containerURL: &containerURL, containerName: containerName, cred: cred}
 	return cl
 }
 
@@ -49,9 +53,156 @@ func (c *azureBlobStoreClient) UploadWithContext(ctx context.Context, path, cont
 		Metadata:         emptyMeta,
 		AccessConditions: emptyBlobAccessCond,
 	}
-	_, err := azblob.UploadStreamToBlockBlob(ctx, content, blobURL, uploadStreamOpts)
+	er := &errReader{r: content}
+	_, err := azblob.UploadStreamToBlockBlob(ctx, er, blobURL, uploadStreamOpts)
 	if err != nil {
 		return errors.E(op, err)
 	}
+	if er.err != nil {
+		return errors.E(op, er.err)
+	}
+	return nil
+}
+
+// errReader records the first error, other than io.EOF, returned by r.
+// UploadStreamToBlockBlob treats every read error as the end of the
+// stream and would report a truncated upload as a success.
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (er *errReader) Read(p []byte) (int, error) {
+	n, err := er.r.Read(p)
+	if err != nil && err != io.EOF && er.err == nil {
+		er.err = err
+	}
+	return n, err
+}
+
+func (c *azureBlobStoreClient) ReadWithContext(ctx context.Context, path string) (io.ReadCloser, error) {
+	const op errors.Op = "azurecdn.ReadWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	blobURL := c.containerURL.NewBlobURL(path)
+	resp, err := blobURL.Download(ctx, 0, 0, azblob.BlobAccessConditions{}, false)
+	if isNotFoundErr(err) {
+		return nil, errors.E(op, err, errors.KindNotFound)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return resp.Body(azblob.RetryReaderOptions{}), nil
+}
+
+func (c *azureBlobStoreClient) ExistsWithContext(ctx context.Context, path string) (bool, error) {
+	const op errors.Op = "azurecdn.ExistsWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	blobURL := c.containerURL.NewBlobURL(path)
+	_, err := blobURL.GetProperties(ctx, azblob.BlobAccessConditions{})
+	if isNotFoundErr(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.E(op, err)
+	}
+	return true, nil
+}
+
+func (c *azureBlobStoreClient) ListWithContext(ctx context.Context, prefix string) ([]string, error) {
+	const op errors.Op = "azurecdn.ListWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	res := []string{}
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		list, err := c.containerURL.ListBlobsFlatSegment(ctx, marker, azblob.ListBlobsSegmentOptions{Prefix: prefix})
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, b := range list.Blobs.Blob {
+			res = append(res, b.Name)
+		}
+		marker = list.NextMarker
+	}
+	return res, nil
+}
+
+func (c *azureBlobStoreClient) WalkWithContext(ctx context.Context, prefix string, fn func(name string) bool) error {
+	const op errors.Op = "azurecdn.WalkWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		list, err := c.containerURL.ListBlobsFlatSegment(ctx, marker, azblob.ListBlobsSegmentOptions{Prefix: prefix})
+		if err != nil {
+			return errors.E(op, err)
+		}
+		for _, b := range list.Blobs.Blob {
+			if !fn(b.Name) {
+				return nil
+			}
+		}
+		marker = list.NextMarker
+	}
 	return nil
 }
+
+func (c *azureBlobStoreClient) WalkDirsWithContext(ctx context.Context, prefix string, fn func(dir string) bool) error {
+	const op errors.Op = "azurecdn.WalkDirsWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		list, err := c.containerURL.ListBlobsHierarchySegment(ctx, marker, "/", azblob.ListBlobsSegmentOptions{Prefix: prefix})
+		if err != nil {
+			return errors.E(op, err)
+		}
+		for _, p := range list.Blobs.BlobPrefix {
+			if !fn(p.Name) {
+				return nil
+			}
+		}
+		marker = list.NextMarker
+	}
+	return nil
+}
+
+func (c *azureBlobStoreClient) DeleteWithContext(ctx context.Context, path string) error {
+	const op errors.Op = "azurecdn.DeleteWithContext"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	blobURL := c.containerURL.NewBlobURL(path)
+	_, err := blobURL.Delete(ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+	if isNotFoundErr(err) {
+		return errors.E(op, err, errors.KindNotFound)
+	}
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// SignedURL returns the URL of the blob at path with a
+// shared access signature that allows to read it until expiry
+func (c *azureBlobStoreClient) SignedURL(path string, expiry time.Duration) url.URL {
+	sas := azblob.BlobSASSignatureValues{
+		Protocol:      azblob.SASProtocolHTTPSandHTTP,
+		ExpiryTime:    time.Now().UTC().Add(expiry),
+		Permissions:   azblob.BlobSASPermissions{Read: true}.String(),
+		ContainerName: c.containerName,
+		BlobName:      path,
+	}
+	parts := azblob.NewBlobURLParts(c.containerURL.NewBlobURL(path).URL())
+	parts.SAS = sas.NewSASQueryParameters(c.cred)
+	return parts.URL()
+}
+
+// isNotFoundErr reports whether err is a response
+// from the blob service for a missing blob
+func isNotFoundErr(err error) bool {
+	serr, ok := err.(azblob.StorageError)
+	if !ok {
+		return false
+	}
+	resp := serr.Response()
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
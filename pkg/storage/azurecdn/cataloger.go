@@ -0,0 +1,26 @@
+package azurecdn
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// Markers of blob listings can not be made up from a name, so
+// it lists the directories that hold the module of token and
+// from there on only the blobs that follow it.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "azurecdn.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	walk := storage.SkippingNameWalker(s.cl.WalkWithContext, s.cl.WalkDirsWithContext)
+	page, next, err := storage.CatalogFromNames(ctx, walk, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
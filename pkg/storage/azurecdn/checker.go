@@ -0,0 +1,22 @@
+package azurecdn
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Exists implements the (./pkg/storage).Checker interface
+// returning true if the module at version exists in storage
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "azurecdn.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.cl.ExistsWithContext(ctx, config.PackageVersionedName(module, version, "info"))
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return exists, nil
+}
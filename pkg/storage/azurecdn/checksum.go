@@ -0,0 +1,51 @@
+package azurecdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "azurecdn.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(sum)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	err = s.cl.UploadWithContext(ctx, config.PackageVersionedName(module, version, "sum"), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "azurecdn.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	sumReader, err := s.cl.ReadWithContext(ctx, config.PackageVersionedName(module, version, "sum"))
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer sumReader.Close()
+
+	b, err := ioutil.ReadAll(sumReader)
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := json.Unmarshal(b, &sum); err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return sum, nil
+}
@@ -0,0 +1,14 @@
+package azurecdn
+
+import (
+	"testing"
+
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
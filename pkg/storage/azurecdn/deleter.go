@@ -0,0 +1,38 @@
+package azurecdn
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	modupl "github.com/gomods/athens/pkg/storage/module"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface and
+// removes a version of a module from storage. Returning ErrNotFound
+// if the version does not exist.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "azurecdn.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	err = modupl.Delete(ctx, module, version, s.cl.DeleteWithContext, s.timeout)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	for _, ext := range []string{"sum", "meta"} {
+		err = s.cl.DeleteWithContext(ctx, config.PackageVersionedName(module, version, ext))
+		if err != nil && !errors.IsNotFoundErr(err) {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package azurecdn
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "azurecdn.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	infoReader, err := s.cl.ReadWithContext(ctx, config.PackageVersionedName(module, version, "info"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer infoReader.Close()
+
+	infoBytes, err := ioutil.ReadAll(infoReader)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return infoBytes, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "azurecdn.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	modReader, err := s.cl.ReadWithContext(ctx, config.PackageVersionedName(module, version, "mod"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer modReader.Close()
+
+	modBytes, err := ioutil.ReadAll(modReader)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return modBytes, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "azurecdn.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	zipReader, err := s.cl.ReadWithContext(ctx, config.PackageVersionedName(module, version, "zip"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	return zipReader, nil
+}
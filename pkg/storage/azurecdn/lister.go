@@ -0,0 +1,43 @@
+package azurecdn
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+)
+
+// List implements the (./pkg/storage).Lister interface
+// It returns a list of versions, if any, for a given module
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "azurecdn.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	blobs, err := s.cl.ListWithContext(ctx, module+"/@v/")
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+
+	versions := []string{}
+	for _, b := range blobs {
+		if v, ok := extractVersion(b); ok {
+			versions = append(versions, v)
+		}
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
+
+// extractVersion returns the version encoded in the
+// name of a .info blob, the other blobs are ignored
+func extractVersion(name string) (string, bool) {
+	if !strings.HasSuffix(name, ".info") {
+		return "", false
+	}
+	segments := strings.Split(name, "/")
+	// version should be last segment w/ .info suffix
+	last := segments[len(segments)-1]
+	return strings.TrimSuffix(last, ".info"), true
+}
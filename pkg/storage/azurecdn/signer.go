@@ -0,0 +1,33 @@
+package azurecdn
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// SignedURL implements the (github.com/gomods/athens/pkg/storage).Signer interface.
+// It returns the URL of the blob with a shared access signature. With a CDN
+// endpoint configured the URL points to the CDN, which passes the signature
+// on to the storage account.
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "azurecdn.SignedURL"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return "", errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return "", errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	u := s.cl.SignedURL(config.PackageVersionedName(module, version, ext), expiry)
+	if cdn, ok := s.cdnConf.CDNURL(u.Path); ok {
+		return cdn + "?" + u.RawQuery, nil
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,47 @@
+package azurecdn
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+)
+
+func (a *AzureTests) TestSignedURL() {
+	r := a.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	_, err := a.storage.SignedURL(ctx, module, version, "zip", time.Minute)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+	r.NoError(a.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	u, err := a.storage.SignedURL(ctx, module, version, "zip", time.Minute)
+	r.NoError(err)
+	parsed, err := url.Parse(u)
+	r.NoError(err)
+	r.Equal("r", parsed.Query().Get("sp"))
+	r.NotEmpty(parsed.Query().Get("sig"))
+	resp, err := http.Get(u)
+	r.NoError(err)
+	defer resp.Body.Close()
+	r.Equal(http.StatusOK, resp.StatusCode)
+	b, err := ioutil.ReadAll(resp.Body)
+	r.NoError(err)
+	r.Equal(zip, b)
+
+	cdn := *a.storage
+	cdn.cdnConf = &config.CDNConfig{Endpoint: "https://cdn.example.com"}
+	u, err = cdn.SignedURL(ctx, module, version, "mod", time.Minute)
+	r.NoError(err)
+	parsed, err = url.Parse(u)
+	r.NoError(err)
+	r.Equal("cdn.example.com", parsed.Host)
+	r.Equal("/devstoreaccount1/gomods/github.com/gomods/athens/@v/v1.2.3.mod", parsed.Path)
+	r.NotEmpty(parsed.Query().Get("sig"))
+}
@@ -1,49 +1,65 @@
 package azurecdn
 
 import (
-	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/errors"
-	"github.com/gomods/athens/pkg/observ"
-	moduploader "github.com/gomods/athens/pkg/storage/module"
 )
 
 type client interface {
 	UploadWithContext(ctx context.Context, path, contentType string, content io.Reader) error
+	ReadWithContext(ctx context.Context, path string) (io.ReadCloser, error)
+	ExistsWithContext(ctx context.Context, path string) (bool, error)
+	ListWithContext(ctx context.Context, prefix string) ([]string, error)
+	// WalkWithContext calls fn with the names of the blobs below prefix
+	// in lexical order until fn returns false or all names were passed
+	WalkWithContext(ctx context.Context, prefix string, fn func(name string) bool) error
+	// WalkDirsWithContext calls fn with the directories right below
+	// prefix, the prefixes of the names of the blobs up to and including
+	// the next "/", in lexical order until fn returns false
+	WalkDirsWithContext(ctx context.Context, prefix string, fn func(dir string) bool) error
+	DeleteWithContext(ctx context.Context, path string) error
+	SignedURL(path string, expiry time.Duration) url.URL
 }
 
-// Storage implements (github.com/gomods/athens/pkg/storage).Saver and
+// Storage implements (github.com/gomods/athens/pkg/storage).Backend and
 // also provides a function to fetch the location of a module
 type Storage struct {
 	cl      client
 	baseURI *url.URL
 	cdnConf *config.CDNConfig
+	timeout time.Duration
 }
 
-// New creates a new azure CDN saver
-func New(accountName, accountKey, containerName string, cdnConf *config.CDNConfig) (*Storage, error) {
+// New creates a new azure blob storage backend
+func New(conf *config.AzureBlobConfig, cdnConf *config.CDNConfig) (*Storage, error) {
 	const op errors.Op = "azurecdn.New"
-	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net", accountName))
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net", conf.AccountName))
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	cl := newBlobStoreClient(u, accountName, accountKey, containerName)
-	return &Storage{cl: cl, baseURI: u, cdnConf: cdnConf}, nil
+	// the azure sdk panics on keys which are not valid base64
+	if _, err := base64.StdEncoding.DecodeString(conf.AccountKey); err != nil {
+		return nil, errors.E(op, fmt.Errorf("invalid account key: %s", err))
+	}
+	cl := newBlobStoreClient(u, conf.AccountName, conf.AccountKey, conf.ContainerName)
+	return &Storage{cl: cl, baseURI: u, cdnConf: cdnConf, timeout: conf.TimeoutDuration()}, nil
 }
 
-// newWithClient creates a new azure CDN saver
-func newWithClient(accountName, cl client, cdnConf *config.CDNConfig) (*Storage, error) {
+// newWithClient creates a new azure blob storage backend
+func newWithClient(accountName string, cl client, cdnConf *config.CDNConfig) (*Storage, error) {
 	const op errors.Op = "azurecdn.newWithClient"
 	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net", accountName))
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	return &Storage{cl: cl, baseURI: u, cdnConf: cdnConf}, nil
+	return &Storage{cl: cl, baseURI: u, cdnConf: cdnConf, timeout: cdnConf.TimeoutDuration()}, nil
 }
 
 // BaseURL returns the base URL that stores all modules. It can be used
@@ -55,18 +71,3 @@ func newWithClient(accountName, cl client, cdnConf *config.CDNConfig) (*Storage,
 func (s Storage) BaseURL() *url.URL {
 	return s.cdnConf.CDNEndpointWithDefault(s.baseURI)
 }
-
-// Save implements the (github.com/gomods/athens/pkg/storage).Saver interface.
-func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
-	const op errors.Op = "azurecdn.Save"
-	ctx, span := observ.StartSpan(ctx, op.String())
-	defer span.End()
-	err := moduploader.Upload(ctx, module, version, bytes.NewReader(info), bytes.NewReader(mod), zip, s.cl.UploadWithContext, s.cdnConf.TimeoutDuration())
-	// TODO: take out lease on the /list file and add the version to it
-	//
-	// Do that only after module source+metadata is uploaded
-	if err != nil {
-		return errors.E(op, err, errors.M(module), errors.V(version))
-	}
-	return nil
-}
@@ -0,0 +1,40 @@
+package azurecdn
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "azurecdn.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	err := s.cl.UploadWithContext(ctx, storage.SumDBPrefix+path, "application/octet-stream", bytes.NewReader(content))
+	if err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "azurecdn.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	rc, err := s.cl.ReadWithContext(ctx, storage.SumDBPrefix+path)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	return content, nil
+}
@@ -0,0 +1,47 @@
+package azurecdn
+
+import (
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// TestSuite implements storage.TestSuite interface
+type TestSuite struct {
+	storage *Storage
+	server  *blobServerMock
+}
+
+// NewTestSuite creates a common test suite backed
+// by an in-process fake of the Azure Blob REST API
+func NewTestSuite() (storage.TestSuite, error) {
+	const account, container = "devstoreaccount1", "gomods"
+	server := newBlobServerMock(account, container)
+	cl := newBlobStoreClient(server.accountURL(), account, mockAccountKey, container)
+	cdnConf := &config.CDNConfig{TimeoutConf: config.TimeoutConf{Timeout: 300}}
+	azStore, err := newWithClient(account, cl, cdnConf)
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	return &TestSuite{
+		storage: azStore,
+		server:  server,
+	}, nil
+}
+
+// Storage retrieves initialized storage backend
+func (ts *TestSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+// StorageHumanReadableName retrieves readable identifier of the storage
+func (ts *TestSuite) StorageHumanReadableName() string {
+	return "Azure Blob"
+}
+
+// Cleanup tears down test
+func (ts *TestSuite) Cleanup() error {
+	ts.server.clear()
+	return nil
+}
@@ -7,4 +7,7 @@ type Backend interface {
 	Checker
 	Saver
 	Deleter
+	Checksummer
+	Statter
+	Cataloger
 }
@@ -0,0 +1,108 @@
+// Package cas implements the (./pkg/storage).Backend interface with a
+// content-addressed layout on top of a plain object store.
+//
+// Every .info, .mod and .zip file is stored once as a blob named after
+// the SHA-256 of its content, and every module version is a small
+// manifest that refers to its three blobs:
+//
+//	blobs/sha256/<first two hex digits>/<hex digest>
+//	manifests/<module>/@v/<version>.json
+//	checksums/<module>/@v/<version>.json
+//	metadata/<module>/@v/<version>.json
+//
+// Versions that share a byte-identical go.mod or zip therefore share the
+// blob, and every blob is verified against its name when it is read.
+//
+// Delete only removes the manifest of a version. Blobs that no manifest
+// refers to anymore are removed by GC.
+package cas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Blobs is the object store Storage keeps its blobs and manifests in.
+// Keys are slash separated paths. The fs, minio and gcp packages
+// provide implementations.
+type Blobs interface {
+	// Open returns the content stored under key,
+	// or an errors.KindNotFound error if there is none
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put stores size bytes of content under key and replaces any
+	// previous content. Readers never see partially written content.
+	Put(ctx context.Context, key string, content io.Reader, size int64) error
+	// Exists returns true if content is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete removes key. Removing a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns all keys that start with prefix,
+	// which always ends in a slash
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+const (
+	blobsPrefix     = "blobs/sha256/"
+	manifestsPrefix = "manifests/"
+	checksumsPrefix = "checksums/"
+	metadataPrefix  = "metadata/"
+	sumDBPrefix     = "sumdb/"
+)
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	blobs Blobs
+	// gcMu keeps GC from removing the blobs of a version
+	// that is being saved but has no manifest yet
+	gcMu sync.RWMutex
+}
+
+// New returns a content-addressed storage that keeps everything in b
+func New(b Blobs) *Storage {
+	return &Storage{blobs: b}
+}
+
+// manifest lists the digests of the blobs that make up a version
+type manifest struct {
+	Info string `json:"info"`
+	Mod  string `json:"mod"`
+	Zip  string `json:"zip"`
+}
+
+func (m manifest) digests() []string {
+	return []string{m.Info, m.Mod, m.Zip}
+}
+
+func hexDigest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func blobKey(digest string) string {
+	return blobsPrefix + digest[:2] + "/" + digest
+}
+
+func manifestsLocation(module string) string {
+	return manifestsPrefix + module + "/@v/"
+}
+
+func manifestKey(module, version string) string {
+	return manifestsLocation(module) + version + ".json"
+}
+
+func checksumKey(module, version string) string {
+	return checksumsPrefix + module + "/@v/" + version + ".json"
+}
+
+func metadataKey(module, version string) string {
+	return metadataPrefix + module + "/@v/" + version + ".json"
+}
+
+// digestOf returns the digest a blob key refers to
+func digestOf(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
@@ -0,0 +1,122 @@
+package cas_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/storage/cas"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const module = "github.com/athens-artifacts/cas"
+
+var (
+	mod = []byte("module " + module)
+	zip = []byte("zip of " + module)
+)
+
+func newStorage(t *testing.T) (*cas.Storage, *fs.Blobs) {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewBlobs("/athens", memFs)
+	require.NoError(t, err)
+	return cas.New(b), b
+}
+
+func save(t *testing.T, s *cas.Storage, version string) {
+	t.Helper()
+	err := s.Save(context.Background(), module, version, mod, bytes.NewReader(zip), []byte(version))
+	require.NoError(t, err)
+}
+
+func blobCount(t *testing.T, b *fs.Blobs) int {
+	t.Helper()
+	keys, err := b.List(context.Background(), "blobs/")
+	require.NoError(t, err)
+	return len(keys)
+}
+
+func TestDedup(t *testing.T) {
+	s, b := newStorage(t)
+	save(t, s, "v1.0.0")
+	require.Equal(t, 3, blobCount(t, b))
+
+	// only the .info file differs
+	save(t, s, "v1.0.1")
+	require.Equal(t, 4, blobCount(t, b))
+
+	versions, err := s.List(context.Background(), module)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.0.1"}, versions)
+}
+
+func TestGC(t *testing.T) {
+	ctx := context.Background()
+	s, b := newStorage(t)
+	save(t, s, "v1.0.0")
+	save(t, s, "v1.0.1")
+
+	require.NoError(t, s.Delete(ctx, module, "v1.0.0"))
+	require.Equal(t, 4, blobCount(t, b))
+
+	removed, err := s.GC(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	require.Equal(t, 3, blobCount(t, b))
+
+	// the shared blobs are still there
+	zipRC, err := s.Zip(ctx, module, "v1.0.1")
+	require.NoError(t, err)
+	defer zipRC.Close()
+	got, err := ioutil.ReadAll(zipRC)
+	require.NoError(t, err)
+	require.Equal(t, zip, got)
+}
+
+func TestGCEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, b := newStorage(t)
+	save(t, s, "v1.0.0")
+	save(t, s, "v1.0.1")
+	require.NoError(t, s.Delete(ctx, module, "v1.0.0"))
+
+	go s.GCEvery(ctx, 10*time.Millisecond, log.New("none", logrus.PanicLevel))
+	deadline := time.Now().Add(5 * time.Second)
+	for blobCount(t, b) > 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, 3, blobCount(t, b))
+}
+
+func TestCorruptBlob(t *testing.T) {
+	ctx := context.Background()
+	s, b := newStorage(t)
+	save(t, s, "v1.0.0")
+
+	keys, err := b.List(ctx, "blobs/")
+	require.NoError(t, err)
+	for _, key := range keys {
+		corrupt := []byte("corrupt")
+		require.NoError(t, b.Put(ctx, key, bytes.NewReader(corrupt), int64(len(corrupt))))
+	}
+
+	_, err = s.Info(ctx, module, "v1.0.0")
+	require.Error(t, err)
+	_, err = s.GoMod(ctx, module, "v1.0.0")
+	require.Error(t, err)
+
+	zipRC, err := s.Zip(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	defer zipRC.Close()
+	_, err = ioutil.ReadAll(zipRC)
+	require.Error(t, err)
+}
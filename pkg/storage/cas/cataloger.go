@@ -0,0 +1,39 @@
+package cas
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It lists all manifests for every page.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "cas.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	keys, err := s.blobs.List(ctx, manifestsPrefix)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	all := []paths.AllPathParams{}
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+		// manifests/<module>/@v/<version>.json
+		name := strings.TrimSuffix(strings.TrimPrefix(key, manifestsPrefix), ".json") + ".info"
+		if p, ok := storage.ParseInfoName(name); ok {
+			all = append(all, p)
+		}
+	}
+	page, next, err := storage.CatalogPage(all, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
@@ -0,0 +1,21 @@
+package cas
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Exists implements the (./pkg/storage).Checker interface.
+// The manifest is written last by Save and marks a complete version.
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "cas.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.blobs.Exists(ctx, manifestKey(module, version))
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return exists, nil
+}
@@ -0,0 +1,43 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "cas.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(sum)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.blobs.Put(ctx, checksumKey(module, version), bytes.NewReader(b), int64(len(b))); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "cas.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	rc, err := s.blobs.Open(ctx, checksumKey(module, version))
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(&sum); err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return sum, nil
+}
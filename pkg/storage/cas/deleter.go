@@ -0,0 +1,34 @@
+package cas
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface.
+// It removes the manifest, checksum and metadata of a version, its blobs
+// may be shared with other versions and are left to GC.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "cas.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	if err := s.blobs.Delete(ctx, manifestKey(module, version)); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.blobs.Delete(ctx, checksumKey(module, version)); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.blobs.Delete(ctx, metadataKey(module, version)); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package cas
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// GC removes the blobs that no manifest refers to and
+// returns how many it removed. Saves of this Storage wait for GC,
+// but GC must not run while another process saves to the same blobs.
+func (s *Storage) GC(ctx context.Context) (int, error) {
+	const op errors.Op = "cas.GC"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	s.gcMu.Lock()
+	defer s.gcMu.Unlock()
+
+	manifests, err := s.blobs.List(ctx, manifestsPrefix)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	used := map[string]bool{}
+	for _, key := range manifests {
+		m, err := s.readManifest(ctx, key)
+		if err != nil {
+			return 0, errors.E(op, err)
+		}
+		for _, d := range m.digests() {
+			used[d] = true
+		}
+	}
+
+	blobs, err := s.blobs.List(ctx, blobsPrefix)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	removed := 0
+	for _, key := range blobs {
+		if used[digestOf(key)] {
+			continue
+		}
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			return removed, errors.E(op, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// GCEvery removes the blobs that no manifest
+// refers to every interval until ctx is done
+func (s *Storage) GCEvery(ctx context.Context, interval time.Duration, lggr log.Entry) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		removed, err := s.GC(ctx)
+		if removed > 0 {
+			lggr.Infof("removed %d files no module version refers to", removed)
+		}
+		if err != nil {
+			lggr.SystemErr(err)
+		}
+	}
+}
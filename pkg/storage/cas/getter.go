@@ -0,0 +1,129 @@
+package cas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "cas.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	m, err := s.manifest(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	info, err := s.readBlob(ctx, m.Info)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "cas.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	m, err := s.manifest(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	mod, err := s.readBlob(ctx, m.Mod)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface.
+// The returned reader fails at the end of the zip
+// if the zip does not match its digest.
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "cas.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	m, err := s.manifest(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	rc, err := s.blobs.Open(ctx, blobKey(m.Zip))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return &verifyingReader{rc: rc, h: sha256.New(), digest: m.Zip}, nil
+}
+
+func (s *Storage) manifest(ctx context.Context, module, version string) (manifest, error) {
+	return s.readManifest(ctx, manifestKey(module, version))
+}
+
+func (s *Storage) readManifest(ctx context.Context, key string) (manifest, error) {
+	const op errors.Op = "cas.readManifest"
+	var m manifest
+	rc, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		return m, errors.E(op, err)
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(&m); err != nil {
+		return m, errors.E(op, err)
+	}
+	return m, nil
+}
+
+// readBlob returns the content of a blob after checking it against its digest
+func (s *Storage) readBlob(ctx context.Context, digest string) ([]byte, error) {
+	const op errors.Op = "cas.readBlob"
+	rc, err := s.blobs.Open(ctx, blobKey(digest))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if got := hexDigest(b); got != digest {
+		return nil, errors.E(op, corruptBlobErr(digest, got))
+	}
+	return b, nil
+}
+
+func corruptBlobErr(digest, got string) error {
+	return fmt.Errorf("blob %s is corrupt, its content has digest %s", digest, got)
+}
+
+// verifyingReader hashes a blob while it is read and
+// returns an error instead of io.EOF if it is corrupt
+type verifyingReader struct {
+	rc     io.ReadCloser
+	h      hash.Hash
+	digest string
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	const op errors.Op = "cas.verifyingReader.Read"
+	n, err := r.rc.Read(p)
+	r.h.Write(p[:n])
+	if err == io.EOF {
+		if got := hex.EncodeToString(r.h.Sum(nil)); got != r.digest {
+			return n, errors.E(op, corruptBlobErr(r.digest, got))
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error {
+	return r.rc.Close()
+}
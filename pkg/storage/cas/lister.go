@@ -0,0 +1,34 @@
+package cas
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+)
+
+// List implements the (./pkg/storage).Lister interface
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "cas.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	loc := manifestsLocation(module)
+	keys, err := s.blobs.List(ctx, loc)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	// module paths never contain "@", so the keys below
+	// <module>/@v/ never belong to a nested module
+	versions := []string{}
+	for _, key := range keys {
+		version := strings.TrimPrefix(key, loc)
+		if strings.Contains(version, "/") || !strings.HasSuffix(version, ".json") {
+			continue
+		}
+		versions = append(versions, strings.TrimSuffix(version, ".json"))
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
@@ -0,0 +1,43 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "cas.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.blobs.Put(ctx, metadataKey(module, version), bytes.NewReader(b), int64(len(b))); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Stat implements the (./pkg/storage).Statter interface
+func (s *Storage) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "cas.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var meta storage.Metadata
+	rc, err := s.blobs.Open(ctx, metadataKey(module, version))
+	if err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(rc).Decode(&meta); err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return meta, nil
+}
@@ -0,0 +1,69 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// It stores the blobs that are not stored yet and then the manifest,
+// which is the commit marker of a version. A failed Save at most
+// leaves blobs behind that no manifest refers to.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "cas.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
+
+	// the name of a blob is only known once all of it has been read
+	zipBytes, err := ioutil.ReadAll(zip)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	s.gcMu.RLock()
+	defer s.gcMu.RUnlock()
+	m := manifest{Info: hexDigest(info), Mod: hexDigest(mod), Zip: hexDigest(zipBytes)}
+	for _, blob := range [][]byte{info, mod, zipBytes} {
+		if err := s.putBlob(ctx, blob); err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.blobs.Put(ctx, manifestKey(module, version), bytes.NewReader(b), int64(len(b))); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// putBlob stores content unless a blob with the same digest is stored already
+func (s *Storage) putBlob(ctx context.Context, content []byte) error {
+	const op errors.Op = "cas.putBlob"
+	key := blobKey(hexDigest(content))
+	exists, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if exists {
+		return nil
+	}
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(content), int64(len(content))); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
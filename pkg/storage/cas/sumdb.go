@@ -0,0 +1,38 @@
+package cas
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "cas.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.blobs.Put(ctx, sumDBPrefix+path, bytes.NewReader(content), int64(len(content))); err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "cas.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	rc, err := s.blobs.Open(ctx, sumDBPrefix+path)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	return content, nil
+}
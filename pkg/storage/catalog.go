@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+)
+
+// CatalogToken returns the token of the page that
+// follows the module version p. Module paths never
+// contain "@", so the token is simply module@version.
+func CatalogToken(p paths.AllPathParams) string {
+	return p.Module + "@" + p.Version
+}
+
+// ParseCatalogToken returns the module version a token refers to.
+// The empty token, which refers to the first page, yields the zero value.
+func ParseCatalogToken(token string) (paths.AllPathParams, error) {
+	const op errors.Op = "storage.ParseCatalogToken"
+	if token == "" {
+		return paths.AllPathParams{}, nil
+	}
+	i := strings.LastIndex(token, "@")
+	if i <= 0 || i == len(token)-1 {
+		return paths.AllPathParams{}, errors.E(op, fmt.Sprintf("invalid catalog token %q", token), errors.KindBadRequest)
+	}
+	return paths.AllPathParams{Module: token[:i], Version: token[i+1:]}, nil
+}
+
+// catalogLess orders module versions by module path and then by version
+func catalogLess(a, b paths.AllPathParams) bool {
+	if a.Module != b.Module {
+		return a.Module < b.Module
+	}
+	return a.Version < b.Version
+}
+
+// SortCatalog sorts module versions into the order of the catalog
+func SortCatalog(all []paths.AllPathParams) {
+	sort.Slice(all, func(i, j int) bool { return catalogLess(all[i], all[j]) })
+}
+
+// CatalogPage implements pagination for backends that enumerate all of
+// their module versions at once. It sorts all and returns the page that
+// follows token along with the token of the next page.
+func CatalogPage(all []paths.AllPathParams, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "storage.CatalogPage"
+	from, err := ParseCatalogToken(token)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	if pageSize <= 0 {
+		return nil, "", errors.E(op, "page size must be positive", errors.KindBadRequest)
+	}
+	SortCatalog(all)
+	start := 0
+	if token != "" {
+		start = sort.Search(len(all), func(i int) bool { return catalogLess(from, all[i]) })
+	}
+	page := all[start:]
+	if len(page) <= pageSize {
+		return page, "", nil
+	}
+	page = page[:pageSize]
+	return page, CatalogToken(page[len(page)-1]), nil
+}
+
+// MergeCatalogPages merges the pages that several backends returned for
+// the same token into one page of at most pageSize module versions and
+// returns it along with the token of the next page. more tells whether
+// any of the backends has a next page.
+func MergeCatalogPages(pages [][]paths.AllPathParams, more bool, pageSize int) ([]paths.AllPathParams, string) {
+	var all []paths.AllPathParams
+	for _, page := range pages {
+		all = append(all, page...)
+	}
+	SortCatalog(all)
+	merged := all[:0]
+	for _, p := range all {
+		if len(merged) == 0 || p != merged[len(merged)-1] {
+			merged = append(merged, p)
+		}
+	}
+	// the versions of a backend beyond its page all sort after its
+	// page, so the first pageSize versions of all pages are complete
+	if len(merged) > pageSize {
+		merged = merged[:pageSize]
+	} else if !more || len(merged) == 0 {
+		return merged, ""
+	}
+	return merged, CatalogToken(merged[len(merged)-1])
+}
+
+// ParseInfoName returns the module version of the name of an .info file
+// in the layout of the download protocol, <module>/@v/<version>.info.
+// It returns false for the names of all other files.
+func ParseInfoName(name string) (paths.AllPathParams, bool) {
+	i := strings.LastIndex(name, "/@v/")
+	if i <= 0 || !strings.HasSuffix(name, ".info") {
+		return paths.AllPathParams{}, false
+	}
+	version := strings.TrimSuffix(name[i+len("/@v/"):], ".info")
+	if version == "" || strings.Contains(version, "/") {
+		return paths.AllPathParams{}, false
+	}
+	return paths.AllPathParams{Module: name[:i], Version: version}, true
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+)
+
+// NameWalker calls fn with the names of the objects of a storage that start
+// with prefix and sort after startAfter, in byte order, until fn returns false
+// or all names were passed. Storages that list names from a given name on,
+// such as S3, implement it directly. SkippingNameWalker implements it for
+// storages that can only list all names below a prefix.
+type NameWalker func(ctx context.Context, prefix, startAfter string, fn func(name string) bool) error
+
+// PrefixWalker calls fn with the names below prefix in byte order until
+// fn returns false or all names were passed
+type PrefixWalker func(ctx context.Context, prefix string, fn func(name string) bool) error
+
+// SkippingNameWalker returns a NameWalker for storages that list names only
+// from the start of a prefix, such as Azure Blob Storage. walkNames lists the
+// names below a prefix and walkDirs the directories directly below it, which
+// are the prefixes of the names up to and including the next "/".
+//
+// The names before startAfter are skipped a directory at a time: the
+// directories that hold startAfter are listed, and of the directories in
+// them only those after startAfter are walked. Names of objects that are
+// right in the directories holding startAfter are not passed to fn, which
+// is fine for the catalog, as .info files are always in a @v directory.
+func SkippingNameWalker(walkNames, walkDirs PrefixWalker) NameWalker {
+	return func(ctx context.Context, prefix, startAfter string, fn func(name string) bool) error {
+		if startAfter == "" || startAfter < prefix {
+			return walkNames(ctx, prefix, fn)
+		}
+		if !strings.HasPrefix(startAfter, prefix) {
+			// every name below prefix sorts before startAfter
+			return nil
+		}
+		stopped := false
+		each := func(name string) bool {
+			stopped = !fn(name)
+			return !stopped
+		}
+		top := prefix[:strings.LastIndex(prefix, "/")+1]
+		dir := startAfter[:strings.LastIndex(startAfter, "/")+1]
+		for {
+			var err error
+			walkErr := walkDirs(ctx, dir, func(sub string) bool {
+				// the directories holding startAfter are walked from
+				// the deeper levels, those before it are skipped
+				if sub <= startAfter || strings.HasPrefix(startAfter, sub) || !strings.HasPrefix(sub, prefix) {
+					return true
+				}
+				err = walkNames(ctx, sub, each)
+				return err == nil && !stopped
+			})
+			if walkErr != nil {
+				return walkErr
+			}
+			if err != nil || stopped || len(dir) <= len(top) {
+				return err
+			}
+			dir = dir[:strings.LastIndex(dir[:len(dir)-1], "/")+1]
+		}
+	}
+}
+
+// CatalogFromNames implements pagination for backends that keep module
+// versions in the layout of the download protocol, <module>/@v/<version>.info,
+// and list the names of their objects in byte order. Rather than listing all
+// names, it lists those after the module of token until a page is complete.
+//
+// Names and module paths do not sort alike: example.com/a/@v/ sorts after
+// example.com/a-b/@v/ while example.com/a sorts before example.com/a-b. Every
+// module version after token still has a name after the module of token, but
+// a module can sort before the modules whose paths it is a prefix of and
+// have names after theirs. Those modules of a page that the listing did not
+// reach are listed one by one.
+func CatalogFromNames(ctx context.Context, walk NameWalker, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "storage.CatalogFromNames"
+	from, err := ParseCatalogToken(token)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	if pageSize <= 0 {
+		return nil, "", errors.E(op, "page size must be positive", errors.KindBadRequest)
+	}
+	found := map[paths.AllPathParams]bool{}
+	add := func(name string) {
+		p, ok := ParseInfoName(name)
+		if ok && (token == "" || catalogLess(from, p)) {
+			found[p] = true
+		}
+	}
+
+	// once more than a page is found, the versions of the module
+	// listed last are completed before the listing stops
+	var last, lastModule string
+	complete := true
+	err = walk(ctx, "", from.Module, func(name string) bool {
+		if strings.HasPrefix(name, SumDBPrefix) {
+			// only checksum database responses follow
+			return false
+		}
+		module := name
+		if i := strings.LastIndex(name, "/@v/"); i >= 0 {
+			module = name[:i]
+		}
+		if len(found) > pageSize && module != lastModule {
+			complete = false
+			return false
+		}
+		add(name)
+		last, lastModule = name, module
+		return true
+	})
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+
+	all := sortedCatalog(found)
+	if !complete {
+		listed := map[string]bool{}
+		for _, p := range all[:pageSize+1] {
+			for i := 1; i < len(p.Module); i++ {
+				prefix := p.Module[:i]
+				if listed[prefix] || prefix < from.Module || prefix+"/@v/" <= last {
+					continue
+				}
+				listed[prefix] = true
+				err := walk(ctx, prefix+"/@v/", "", func(name string) bool {
+					add(name)
+					return true
+				})
+				if err != nil {
+					return nil, "", errors.E(op, err)
+				}
+			}
+		}
+		all = sortedCatalog(found)
+	}
+	if len(all) <= pageSize {
+		return all, "", nil
+	}
+	page := all[:pageSize]
+	return page, CatalogToken(page[pageSize-1]), nil
+}
+
+func sortedCatalog(found map[paths.AllPathParams]bool) []paths.AllPathParams {
+	all := make([]paths.AllPathParams, 0, len(found))
+	for p := range found {
+		all = append(all, p)
+	}
+	SortCatalog(all)
+	return all
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/paths"
+)
+
+// Cataloger is the interface that enumerates all module versions in a storage
+type Cataloger interface {
+	// Catalog returns up to pageSize module versions that follow the one
+	// token refers to, ordered by module path and then by version.
+	// An empty token returns the first page. The returned token refers to
+	// the next page and is empty once there are no more module versions.
+	// An invalid token yields an errors.KindBadRequest error.
+	Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error)
+}
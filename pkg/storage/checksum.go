@@ -0,0 +1,27 @@
+package storage
+
+import "context"
+
+// Checksum holds the go.sum hashes (h1:...) of a module version
+type Checksum struct {
+	// Sum is the hash of the zip file
+	Sum string `json:"Sum"`
+	// GoModSum is the hash of the go.mod file
+	GoModSum string `json:"GoModSum"`
+	// ZipHash is the hex encoded SHA-256 of the zip file as stored.
+	// Unlike Sum it can be computed while the zip is read, so reads
+	// are verified as they stream. It is empty for checksums recorded
+	// before it was introduced.
+	ZipHash string `json:"ZipHash,omitempty"`
+}
+
+// Checksummer stores the go.sum hashes of module versions alongside them,
+// so that their content can be verified whenever it is read back
+type Checksummer interface {
+	// SaveChecksum stores the hashes of a module version,
+	// replacing the ones stored before
+	SaveChecksum(ctx context.Context, module, version string, sum Checksum) error
+	// Checksum returns the hashes of a module version.
+	// It returns ErrNotFound if no hashes are stored
+	Checksum(ctx context.Context, module, version string) (Checksum, error)
+}
@@ -0,0 +1,435 @@
+// Package compliance provides a conformance test harness for
+// (github.com/gomods/athens/pkg/storage).Backend implementations.
+//
+// Every backend in pkg/storage runs RunTests from its own tests.
+// Third-party backends can do the same to check that they honor the
+// contract the proxy relies on:
+//
+//   - Info, GoMod and Zip return exactly what was saved
+//   - a missing module version yields errors.KindNotFound from the
+//     getters and Delete, an empty List and a false Exists
+//   - List returns the versions of exactly one module, in ascending order
+//   - a failed Save leaves no trace of the version visible to readers
+//   - concurrent saves of the same version either succeed or fail with
+//     errors.KindAlreadyExists, and leave a single intact copy behind
+//   - checksums and metadata are stored independently of the version they
+//     describe and are removed along with it by Delete
+//   - module paths are stored verbatim, so uppercase, "!"-escaped and
+//     major version suffixed paths never collide
+//   - Catalog pages through every stored version exactly once, in order,
+//     and agrees with List
+package compliance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/semver"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+// largeZipSize is big enough to span several
+// upload parts or chunks on every backend
+const largeZipSize = 10 * 1024 * 1024
+
+// RunTests runs the conformance tests against the backend of ts.
+// The backend is cleaned up before and after the run.
+func RunTests(t *testing.T, ts storage.TestSuite) {
+	hrn := ts.StorageHumanReadableName()
+	require.NoError(t, ts.Cleanup(), "cleanup of %s failed", hrn)
+	defer func() {
+		require.NoError(t, ts.Cleanup(), "cleanup of %s failed", hrn)
+	}()
+
+	b := ts.Storage()
+	t.Run("NotFound", func(t *testing.T) { testNotFound(t, b) })
+	t.Run("RoundTrip", func(t *testing.T) { testRoundTrip(t, b) })
+	t.Run("ListOrder", func(t *testing.T) { testListOrder(t, b) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, b) })
+	t.Run("FailedSave", func(t *testing.T) { testFailedSave(t, b) })
+	t.Run("ConcurrentSaves", func(t *testing.T) { testConcurrentSaves(t, b) })
+	t.Run("LargeZip", func(t *testing.T) { testLargeZip(t, b) })
+	t.Run("UnusualPaths", func(t *testing.T) { testUnusualPaths(t, b) })
+	t.Run("Checksum", func(t *testing.T) { testChecksum(t, b) })
+	t.Run("Metadata", func(t *testing.T) { testMetadata(t, b) })
+	t.Run("Catalog", func(t *testing.T) { testCatalog(t, b) })
+	t.Run("SumDB", func(t *testing.T) { testSumDB(t, b) })
+}
+
+type moduleVersion struct {
+	module  string
+	version string
+	mod     []byte
+	zip     []byte
+	info    []byte
+}
+
+func newModuleVersion(module, version string) moduleVersion {
+	return moduleVersion{
+		module:  module,
+		version: version,
+		mod:     []byte(fmt.Sprintf("module %s", module)),
+		zip:     []byte(fmt.Sprintf("zip of %s@%s", module, version)),
+		info:    []byte(fmt.Sprintf(`{"Version":%q}`, version)),
+	}
+}
+
+func save(t *testing.T, b storage.Backend, mv moduleVersion) {
+	err := b.Save(context.Background(), mv.module, mv.version, mv.mod, bytes.NewReader(mv.zip), mv.info)
+	require.NoError(t, err, "saving %s@%s", mv.module, mv.version)
+}
+
+func requireStored(t *testing.T, b storage.Backend, mv moduleVersion) {
+	ctx := context.Background()
+	exists, err := b.Exists(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	require.True(t, exists, "%s@%s should exist", mv.module, mv.version)
+
+	info, err := b.Info(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	require.Equal(t, mv.info, info)
+
+	mod, err := b.GoMod(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	require.Equal(t, mv.mod, mod)
+
+	zipRC, err := b.Zip(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	zip, err := ioutil.ReadAll(zipRC)
+	require.NoError(t, zipRC.Close())
+	require.NoError(t, err)
+	// bytes.Equal keeps the output of large mismatches readable
+	require.True(t, bytes.Equal(mv.zip, zip), "zip of %s@%s does not match what was saved", mv.module, mv.version)
+}
+
+func requireNotFound(t *testing.T, b storage.Backend, module, version string) {
+	ctx := context.Background()
+	exists, err := b.Exists(ctx, module, version)
+	require.NoError(t, err)
+	require.False(t, exists, "%s@%s should not exist", module, version)
+
+	_, err = b.Info(ctx, module, version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Info: %v", err)
+
+	_, err = b.GoMod(ctx, module, version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "GoMod: %v", err)
+
+	zip, err := b.Zip(ctx, module, version)
+	if err == nil {
+		zip.Close()
+	}
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Zip: %v", err)
+
+	err = b.Delete(ctx, module, version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Delete: %v", err)
+}
+
+func requireList(t *testing.T, b storage.Backend, module string, expected []string) {
+	versions, err := b.List(context.Background(), module)
+	require.NoError(t, err)
+	if len(expected) == 0 {
+		require.Empty(t, versions, "no versions of %s expected", module)
+		return
+	}
+	require.Equal(t, expected, versions, "versions of %s", module)
+}
+
+func testNotFound(t *testing.T, b storage.Backend) {
+	requireNotFound(t, b, "compliance.test/notfound", "v1.0.0")
+	requireList(t, b, "compliance.test/notfound", nil)
+}
+
+func testRoundTrip(t *testing.T, b storage.Backend) {
+	mv := newModuleVersion("compliance.test/roundtrip", "v1.0.0")
+	save(t, b, mv)
+	requireStored(t, b, mv)
+	requireList(t, b, mv.module, []string{mv.version})
+}
+
+func testListOrder(t *testing.T, b storage.Backend) {
+	const module = "compliance.test/listorder"
+	// semver order differs from the lexical order of these versions
+	for _, version := range []string{"v1.10.0", "v1.1.0", "v2.0.0", "v1.9.0", "v1.10.0-rc.1"} {
+		save(t, b, newModuleVersion(module, version))
+	}
+	requireList(t, b, module, []string{"v1.1.0", "v1.9.0", "v1.10.0-rc.1", "v1.10.0", "v2.0.0"})
+}
+
+func testDelete(t *testing.T, b storage.Backend) {
+	deleted := newModuleVersion("compliance.test/delete", "v1.0.0")
+	kept := newModuleVersion("compliance.test/delete", "v1.1.0")
+	save(t, b, deleted)
+	save(t, b, kept)
+
+	require.NoError(t, b.Delete(context.Background(), deleted.module, deleted.version))
+
+	requireNotFound(t, b, deleted.module, deleted.version)
+	requireStored(t, b, kept)
+	requireList(t, b, kept.module, []string{kept.version})
+}
+
+// failingReader returns some data and then an error,
+// like a zip stream from an upstream that broke off
+type failingReader struct {
+	served bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.served {
+		return 0, fmt.Errorf("connection reset")
+	}
+	r.served = true
+	return copy(p, "partial zip"), nil
+}
+
+func testFailedSave(t *testing.T, b storage.Backend) {
+	mv := newModuleVersion("compliance.test/failedsave", "v1.0.0")
+	err := b.Save(context.Background(), mv.module, mv.version, mv.mod, &failingReader{}, mv.info)
+	require.Error(t, err)
+
+	requireNotFound(t, b, mv.module, mv.version)
+	requireList(t, b, mv.module, nil)
+
+	// the version can still be saved afterwards
+	save(t, b, mv)
+	requireStored(t, b, mv)
+}
+
+func testConcurrentSaves(t *testing.T, b storage.Backend) {
+	const saves = 8
+	mv := newModuleVersion("compliance.test/concurrent", "v1.0.0")
+
+	var wg sync.WaitGroup
+	errs := make([]error, saves)
+	for i := 0; i < saves; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = b.Save(context.Background(), mv.module, mv.version, mv.mod, bytes.NewReader(mv.zip), mv.info)
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.Equal(t, errors.KindAlreadyExists, errors.Kind(err), "concurrent Save: %v", err)
+	}
+	require.True(t, succeeded > 0, "at least one concurrent Save should succeed")
+
+	requireStored(t, b, mv)
+	requireList(t, b, mv.module, []string{mv.version})
+}
+
+func testLargeZip(t *testing.T, b storage.Backend) {
+	mv := newModuleVersion("compliance.test/largezip", "v1.0.0")
+	mv.zip = make([]byte, largeZipSize)
+	rand.New(rand.NewSource(1)).Read(mv.zip)
+
+	save(t, b, mv)
+	requireStored(t, b, mv)
+}
+
+func testUnusualPaths(t *testing.T, b storage.Backend) {
+	mvs := []moduleVersion{
+		newModuleVersion("github.com/Azure/azure-sdk-for-go", "v1.0.0"),
+		newModuleVersion("github.com/!azure/azure-sdk-for-go", "v1.1.0"),
+		newModuleVersion("compliance.test/major", "v1.0.0"),
+		newModuleVersion("compliance.test/major/v2", "v2.0.0"),
+		newModuleVersion("compliance.test/major/v2", "v2.1.0-rc.1"),
+		newModuleVersion("compliance.test/incompatible", "v3.0.0+incompatible"),
+		newModuleVersion("compliance.test/pseudo", "v0.0.0-20180910181607-0e37d006457b"),
+		newModuleVersion("gopkg.in/yaml.v2", "v2.2.1"),
+	}
+	for _, mv := range mvs {
+		save(t, b, mv)
+	}
+	for _, mv := range mvs {
+		requireStored(t, b, mv)
+	}
+
+	requireList(t, b, "github.com/Azure/azure-sdk-for-go", []string{"v1.0.0"})
+	requireList(t, b, "github.com/!azure/azure-sdk-for-go", []string{"v1.1.0"})
+	requireList(t, b, "compliance.test/major", []string{"v1.0.0"})
+	requireList(t, b, "compliance.test/major/v2", []string{"v2.0.0", "v2.1.0-rc.1"})
+	requireList(t, b, "compliance.test/majo", nil)
+	requireList(t, b, "compliance.test/incompatible", []string{"v3.0.0+incompatible"})
+	requireList(t, b, "compliance.test/pseudo", []string{"v0.0.0-20180910181607-0e37d006457b"})
+	requireList(t, b, "gopkg.in/yaml.v2", []string{"v2.2.1"})
+	requireNotFound(t, b, "compliance.test/major", "v2")
+}
+
+func testChecksum(t *testing.T, b storage.Backend) {
+	ctx := context.Background()
+	mv := newModuleVersion("compliance.test/checksum", "v1.0.0")
+	_, err := b.Checksum(ctx, mv.module, mv.version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Checksum: %v", err)
+
+	// a checksum alone does not make a version
+	sum := storage.Checksum{Sum: "h1:zip", GoModSum: "h1:mod", ZipHash: "0123abcd"}
+	require.NoError(t, b.SaveChecksum(ctx, mv.module, mv.version, sum))
+	requireNotFound(t, b, mv.module, mv.version)
+	requireList(t, b, mv.module, nil)
+
+	save(t, b, mv)
+	requireStored(t, b, mv)
+	stored, err := b.Checksum(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+
+	sum = storage.Checksum{Sum: "h1:otherzip", GoModSum: "h1:othermod"}
+	require.NoError(t, b.SaveChecksum(ctx, mv.module, mv.version, sum))
+	stored, err = b.Checksum(ctx, mv.module, mv.version)
+	require.NoError(t, err)
+	require.Equal(t, sum, stored)
+
+	require.NoError(t, b.Delete(ctx, mv.module, mv.version))
+	_, err = b.Checksum(ctx, mv.module, mv.version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Checksum after Delete: %v", err)
+}
+
+func testMetadata(t *testing.T, b storage.Backend) {
+	ctx := context.Background()
+	mv := newModuleVersion("compliance.test/metadata", "v1.0.0")
+	_, err := b.Stat(ctx, mv.module, mv.version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Stat: %v", err)
+
+	save(t, b, mv)
+	// backends may store timestamps with millisecond precision only
+	meta := storage.Metadata{
+		ZipSize:   int64(len(mv.zip)),
+		FetchedAt: time.Date(2018, 10, 1, 12, 30, 0, 0, time.UTC),
+		Origin:    "compliance",
+		Checksum:  storage.Checksum{Sum: "h1:zip", GoModSum: "h1:mod", ZipHash: "0123abcd"},
+	}
+	require.NoError(t, b.SaveMetadata(ctx, mv.module, mv.version, meta))
+	requireMetadata(t, b, mv, meta)
+
+	meta.Origin = "compliance again"
+	meta.FetchedAt = meta.FetchedAt.Add(time.Hour)
+	require.NoError(t, b.SaveMetadata(ctx, mv.module, mv.version, meta))
+	requireMetadata(t, b, mv, meta)
+
+	require.NoError(t, b.Delete(ctx, mv.module, mv.version))
+	_, err = b.Stat(ctx, mv.module, mv.version)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "Stat after Delete: %v", err)
+}
+
+func requireMetadata(t *testing.T, b storage.Backend, mv moduleVersion, expected storage.Metadata) {
+	t.Helper()
+	meta, err := b.Stat(context.Background(), mv.module, mv.version)
+	require.NoError(t, err)
+	require.True(t, expected.FetchedAt.Equal(meta.FetchedAt), "FetchedAt: expected %v, got %v", expected.FetchedAt, meta.FetchedAt)
+	meta.FetchedAt = expected.FetchedAt
+	require.Equal(t, expected, meta)
+}
+
+// catalog pages through the whole catalog of b
+func catalog(t *testing.T, b storage.Backend, pageSize int) []paths.AllPathParams {
+	var all []paths.AllPathParams
+	token := ""
+	for {
+		page, next, err := b.Catalog(context.Background(), token, pageSize)
+		require.NoError(t, err, "Catalog page after %q", token)
+		require.True(t, len(page) <= pageSize, "Catalog page of %d exceeds the page size %d", len(page), pageSize)
+		all = append(all, page...)
+		if next == "" {
+			return all
+		}
+		require.NotEmpty(t, page, "only the last page may be empty")
+		token = next
+	}
+}
+
+func testCatalog(t *testing.T, b storage.Backend) {
+	ctx := context.Background()
+	mvs := []moduleVersion{
+		newModuleVersion("compliance.test/catalog", "v1.0.0"),
+		newModuleVersion("compliance.test/catalog", "v1.1.0"),
+		newModuleVersion("compliance.test/catalog", "v1.10.0"),
+		newModuleVersion("compliance.test/catalog/v2", "v2.0.0"),
+		newModuleVersion("compliance.test/catalog-other", "v0.1.0"),
+		// the names of these modules sort before those of
+		// compliance.test/catalog on backends that list names
+		newModuleVersion("compliance.test/catalog.v3", "v3.0.0"),
+		newModuleVersion("compliance.test/catalog/0x", "v0.1.0"),
+	}
+	for _, mv := range mvs {
+		save(t, b, mv)
+	}
+	// neither checksums nor deleted versions are in the catalog
+	sumOnly := newModuleVersion("compliance.test/catalog", "v0.9.0")
+	require.NoError(t, b.SaveChecksum(ctx, sumOnly.module, sumOnly.version, storage.Checksum{Sum: "h1:zip"}))
+	deleted := newModuleVersion("compliance.test/catalog", "v1.2.0")
+	save(t, b, deleted)
+	require.NoError(t, b.Delete(ctx, deleted.module, deleted.version))
+
+	all := catalog(t, b, 1000)
+	require.Equal(t, all, catalog(t, b, 2), "catalog with a page size of 2")
+	require.Equal(t, all, catalog(t, b, 1), "catalog with a page size of 1")
+
+	versions := map[string][]string{}
+	for i, p := range all {
+		if i > 0 {
+			prev := all[i-1]
+			ordered := prev.Module < p.Module || (prev.Module == p.Module && prev.Version < p.Version)
+			require.True(t, ordered, "catalog is out of order at %s@%s", p.Module, p.Version)
+		}
+		versions[p.Module] = append(versions[p.Module], p.Version)
+	}
+	for _, mv := range mvs {
+		require.Contains(t, versions[mv.module], mv.version, "catalog lacks %s@%s", mv.module, mv.version)
+	}
+	// the catalog orders versions lexically, List by semver
+	for module, vs := range versions {
+		semver.Sort(vs)
+		requireList(t, b, module, vs)
+	}
+	require.NotContains(t, versions[sumOnly.module], sumOnly.version)
+	require.NotContains(t, versions[deleted.module], deleted.version)
+
+	_, _, err := b.Catalog(ctx, "not a token", 10)
+	require.Equal(t, errors.KindBadRequest, errors.Kind(err), "Catalog with an invalid token: %v", err)
+}
+
+func testSumDB(t *testing.T, b storage.Backend) {
+	ctx := context.Background()
+	lookup := "sum.golang.org/lookup/compliance.test/sumdb@v1.0.0"
+	tile := "sum.golang.org/tile/8/0/x001/234.p/5"
+	_, err := storage.SumDB(ctx, b, lookup)
+	if errors.Kind(err) == errors.KindNotImplemented {
+		t.Skip("storage can not cache checksum databases")
+	}
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "SumDB: %v", err)
+
+	require.NoError(t, storage.SaveSumDB(ctx, b, lookup, []byte("record")))
+	require.NoError(t, storage.SaveSumDB(ctx, b, tile, []byte("tile")))
+	for path, expected := range map[string]string{lookup: "record", tile: "tile"} {
+		content, err := storage.SumDB(ctx, b, path)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(content), path)
+	}
+	require.NoError(t, storage.SaveSumDB(ctx, b, lookup, []byte("record again")))
+	content, err := storage.SumDB(ctx, b, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "record again", string(content))
+
+	// responses are neither versions nor in the catalog
+	requireList(t, b, "sum.golang.org", nil)
+	for _, p := range catalog(t, b, 1000) {
+		require.False(t, strings.HasPrefix(p.Module, "sum.golang.org"), "catalog has %s@%s", p.Module, p.Version)
+	}
+}
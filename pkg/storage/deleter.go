@@ -5,6 +5,6 @@ import "context"
 // Deleter deletes module metadata and its source from underlying storage
 type Deleter interface {
 	// Delete must return ErrNotFound if the module/version are not
-	// found.
+	// found. The checksum of the version is removed along with it.
 	Delete(ctx context.Context, module, vsn string) error
 }
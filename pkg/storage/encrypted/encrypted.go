@@ -0,0 +1,94 @@
+// Package encrypted provides a storage backend wrapper that encrypts the
+// .info, .mod and .zip files of module versions with AES-GCM before they
+// are saved to another backend, and decrypts them when they are read.
+// Zips are encrypted in chunks of 64 KiB, each with its own nonce, so
+// that they stream through the wrapper like through any other backend.
+//
+// Every file is stored with the ID of the key it was encrypted with, so
+// keys can be rotated by adding a new primary key to the keyring. Files
+// encrypted with an older key stay readable as long as that key is in
+// the keyring. To encrypt them with the new key, copy the versions to a
+// new storage with cmd/migrate.
+//
+// Each file is bound to its module, version and kind, so that files can
+// not be swapped within the wrapped backend without failing to decrypt.
+// Checksums, metadata, version lists and the catalog are not encrypted.
+package encrypted
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/quota"
+)
+
+// formatVersion is the first byte of the .info and .mod files. It is
+// followed by the length of the key ID, the key ID, the nonce and the
+// sealed payload.
+const formatVersion = 1
+
+// Storage encrypts the files of module versions saved to the
+// wrapped storage.Backend. Reading a file that was not encrypted,
+// or was encrypted with a key that is not in the keyring, fails.
+type Storage struct {
+	storage.Backend
+	keys *Keyring
+}
+
+// New returns a Storage that encrypts the files saved to b with keys
+func New(b storage.Backend, keys *Keyring) *Storage {
+	return &Storage{Backend: b, keys: keys}
+}
+
+// Stats implements the (./pkg/storage/quota).Statser interface
+// if the wrapped backend implements it
+func (s *Storage) Stats() (quota.Stats, error) {
+	return quota.StatsOf(s.Backend)
+}
+
+// seal encrypts the ext file of module@version with the primary key
+func (s *Storage) seal(module, version, ext string, plaintext []byte) ([]byte, error) {
+	id := s.keys.primary
+	aead := s.keys.keys[id]
+	header := make([]byte, 0, 2+len(id)+aead.NonceSize())
+	header = append(header, formatVersion, byte(len(id)))
+	header = append(header, id...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	header = append(header, nonce...)
+	return aead.Seal(header, nonce, plaintext, additionalData(module, version, ext)), nil
+}
+
+// open decrypts the ext file of module@version
+func (s *Storage) open(module, version, ext string, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2 || ciphertext[0] != formatVersion {
+		return nil, fmt.Errorf("%s file is not encrypted", ext)
+	}
+	idLen := int(ciphertext[1])
+	if len(ciphertext) < 2+idLen {
+		return nil, fmt.Errorf("%s file is truncated", ext)
+	}
+	id := string(ciphertext[2 : 2+idLen])
+	aead, ok := s.keys.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%s file is encrypted with key %q, which is not in the keyring", ext, id)
+	}
+	rest := ciphertext[2+idLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("%s file is truncated", ext)
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData(module, version, ext))
+	if err != nil {
+		return nil, fmt.Errorf("%s file can not be decrypted with key %q: %s", ext, id, err)
+	}
+	return plaintext, nil
+}
+
+func additionalData(module, version, ext string) []byte {
+	return []byte(module + "@" + version + "." + ext)
+}
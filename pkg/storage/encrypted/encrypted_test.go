@@ -0,0 +1,249 @@
+package encrypted_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/gomods/athens/pkg/storage/encrypted"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	module  = "github.com/athens-artifacts/encrypted"
+	version = "v1.0.0"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 32)
+	mod  = []byte("module " + module)
+	zip  = []byte("proprietary code")
+	info = []byte(`{"Version":"v1.0.0"}`)
+)
+
+func newBackend(t *testing.T) storage.Backend {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return b
+}
+
+func newKeyring(t *testing.T, primary string) *encrypted.Keyring {
+	kr, err := encrypted.NewKeyring(primary, map[string][]byte{"k1": key1, "k2": key2})
+	require.NoError(t, err)
+	return kr
+}
+
+// testSuite runs the compliance tests against
+// an encrypted backend in memory
+type testSuite struct {
+	t       *testing.T
+	storage *encrypted.Storage
+}
+
+func (ts *testSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+func (ts *testSuite) StorageHumanReadableName() string {
+	return "Encrypted"
+}
+
+func (ts *testSuite) Cleanup() error {
+	*ts.storage = *encrypted.New(newBackend(ts.t), newKeyring(ts.t, "k1"))
+	return nil
+}
+
+func TestBackendCompliance(t *testing.T) {
+	ts := &testSuite{t: t, storage: encrypted.New(newBackend(t), newKeyring(t, "k1"))}
+	compliance.RunTests(t, ts)
+}
+
+func read(t *testing.T, b storage.Backend) (mod, zip, info []byte) {
+	t.Helper()
+	ctx := context.Background()
+	mod, err := b.GoMod(ctx, module, version)
+	require.NoError(t, err)
+	info, err = b.Info(ctx, module, version)
+	require.NoError(t, err)
+	rc, err := b.Zip(ctx, module, version)
+	require.NoError(t, err)
+	defer rc.Close()
+	zip, err = ioutil.ReadAll(rc)
+	require.NoError(t, err)
+	return mod, zip, info
+}
+
+func TestStoredEncrypted(t *testing.T) {
+	b := newBackend(t)
+	s := encrypted.New(b, newKeyring(t, "k1"))
+	require.NoError(t, s.Save(context.Background(), module, version, mod, bytes.NewReader(zip), info))
+
+	gotMod, gotZip, gotInfo := read(t, s)
+	require.Equal(t, mod, gotMod)
+	require.Equal(t, zip, gotZip)
+	require.Equal(t, info, gotInfo)
+
+	storedMod, storedZip, storedInfo := read(t, b)
+	require.NotContains(t, string(storedMod), string(mod))
+	require.NotContains(t, string(storedZip), string(zip))
+	require.NotContains(t, string(storedInfo), string(info))
+}
+
+func TestKeyRotation(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+	require.NoError(t, encrypted.New(b, newKeyring(t, "k1")).Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	rotated := encrypted.New(b, newKeyring(t, "k2"))
+	gotMod, _, _ := read(t, rotated)
+	require.Equal(t, mod, gotMod)
+	require.NoError(t, rotated.Save(ctx, module, "v2.0.0", mod, bytes.NewReader(zip), info))
+
+	// without k1 only the version saved after the rotation is readable
+	kr, err := encrypted.NewKeyring("k2", map[string][]byte{"k2": key2})
+	require.NoError(t, err)
+	s := encrypted.New(b, kr)
+	_, err = s.GoMod(ctx, module, version)
+	require.Error(t, err)
+	_, err = s.GoMod(ctx, module, "v2.0.0")
+	require.NoError(t, err)
+}
+
+func TestTamperedFiles(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+	s := encrypted.New(b, newKeyring(t, "k1"))
+	require.NoError(t, s.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	// files of one version can not be passed off as another's
+	storedMod, storedZip, storedInfo := read(t, b)
+	require.NoError(t, b.Save(ctx, module, "v1.0.1", storedMod, bytes.NewReader(storedZip), storedInfo))
+	_, err := s.GoMod(ctx, module, "v1.0.1")
+	require.Error(t, err)
+	_, err = s.Zip(ctx, module, "v1.0.1")
+	require.Error(t, err)
+
+	// plain files are not served
+	require.NoError(t, b.Save(ctx, module, "v1.0.2", mod, bytes.NewReader(zip), info))
+	_, err = s.Info(ctx, module, "v1.0.2")
+	require.Error(t, err)
+}
+
+func TestStreamedZips(t *testing.T) {
+	ctx := context.Background()
+	s := encrypted.New(newBackend(t), newKeyring(t, "k1"))
+	chunk := 64 * 1024
+	for _, size := range []int{0, 1, chunk - 1, chunk, chunk + 1, 3 * chunk} {
+		ver := fmt.Sprintf("v0.0.%d", size)
+		content := make([]byte, size)
+		rand.Read(content)
+		require.NoError(t, s.Save(ctx, module, ver, mod, bytes.NewReader(content), info))
+
+		rc, err := s.Zip(ctx, module, ver)
+		require.NoError(t, err)
+		got, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, content, got, "size %d", size)
+
+		// the zips of the fs backend can be seeked, and so can their plaintext
+		rs, ok := rc.(storage.SizeReadSeekCloser)
+		require.True(t, ok)
+		require.Equal(t, int64(size), rs.Size())
+		for _, off := range []int{0, size / 2, size - 1, size} {
+			if off < 0 {
+				continue
+			}
+			pos, err := rs.Seek(int64(off), io.SeekStart)
+			require.NoError(t, err)
+			require.Equal(t, int64(off), pos)
+			got, err := ioutil.ReadAll(rs)
+			require.NoError(t, err)
+			require.Equal(t, content[off:], got, "size %d offset %d", size, off)
+		}
+		require.NoError(t, rc.Close())
+	}
+}
+
+func TestTamperedChunks(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+	s := encrypted.New(b, newKeyring(t, "k1"))
+	content := bytes.Repeat([]byte("x"), 3*64*1024)
+	require.NoError(t, s.Save(ctx, module, version, mod, bytes.NewReader(content), info))
+	storedMod, storedZip, storedInfo := read(t, b)
+
+	// the header is the format, the length of the key ID and
+	// "k1", and every chunk is a nonce, 64 KiB and a tag
+	header, sealedChunk := 4, 12+64*1024+16
+	last := storedZip[header+2*sealedChunk:]
+	for name, tampered := range map[string][]byte{
+		"dropped":   storedZip[:header+2*sealedChunk],
+		"reordered": append(append(append(append([]byte{}, storedZip[:header]...), storedZip[header+sealedChunk:header+2*sealedChunk]...), storedZip[header:header+sealedChunk]...), last...),
+	} {
+		require.NoError(t, b.Delete(ctx, module, version))
+		require.NoError(t, b.Save(ctx, module, version, storedMod, bytes.NewReader(tampered), storedInfo))
+		rc, err := s.Zip(ctx, module, version)
+		if err == nil {
+			_, err = ioutil.ReadAll(rc)
+			rc.Close()
+		}
+		require.Error(t, err, name)
+	}
+}
+
+func TestStatsPassThrough(t *testing.T) {
+	_, err := encrypted.New(newBackend(t), newKeyring(t, "k1")).Stats()
+	require.Equal(t, errors.KindNotImplemented, errors.Kind(err))
+
+	q := quota.New(newBackend(t), 1024*1024, nil, nil)
+	s := encrypted.New(q, newKeyring(t, "k1"))
+	require.NoError(t, s.Save(context.Background(), module, version, mod, bytes.NewReader(zip), info))
+	stats, err := s.Stats()
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Versions)
+}
+
+func TestReadKeyring(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keyring.json")
+	write := func(primary string, keys map[string]string) {
+		b, err := json.Marshal(map[string]interface{}{"primary": primary, "keys": keys})
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, b, 0600))
+	}
+
+	write("k1", map[string]string{"k1": base64.StdEncoding.EncodeToString(key1)})
+	_, err = encrypted.ReadKeyring(path)
+	require.NoError(t, err)
+
+	write("k2", map[string]string{"k1": base64.StdEncoding.EncodeToString(key1)})
+	_, err = encrypted.ReadKeyring(path)
+	require.Error(t, err, "missing primary key")
+
+	write("k1", map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))})
+	_, err = encrypted.ReadKeyring(path)
+	require.Error(t, err, "invalid key size")
+
+	write("k1", map[string]string{"k1": "not base64"})
+	_, err = encrypted.ReadKeyring(path)
+	require.Error(t, err, "invalid encoding")
+}
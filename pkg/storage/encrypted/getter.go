@@ -0,0 +1,60 @@
+package encrypted
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "encrypted.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	sealed, err := s.Backend.Info(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	info, err := s.open(module, version, "info", sealed)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "encrypted.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	sealed, err := s.Backend.GoMod(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	mod, err := s.open(module, version, "mod", sealed)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface. The zip is
+// decrypted as it is read, and the returned reader implements
+// storage.SizeReadSeekCloser if the zip of the wrapped backend does.
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "encrypted.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	rc, err := s.Backend.Zip(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	zip, err := s.openZip(module, version, rc)
+	if err != nil {
+		rc.Close()
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return zip, nil
+}
@@ -0,0 +1,89 @@
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// maxKeyIDLen is the longest key ID that fits the payload header
+const maxKeyIDLen = 255
+
+// Keyring holds the keys that payloads are encrypted with. New payloads
+// are encrypted with the primary key. The other keys are kept to decrypt
+// the payloads that were encrypted with them before a key rotation.
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// keyringFile is the JSON layout of a keyring file, e.g.
+//
+//	{
+//		"primary": "2018-11",
+//		"keys": {
+//			"2018-10": "<base64 encoded key>",
+//			"2018-11": "<base64 encoded key>"
+//		}
+//	}
+type keyringFile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// NewKeyring returns a Keyring of AES-128, AES-192 or AES-256 keys
+// by their ID, where primary is the ID of the key to encrypt with.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	const op errors.Op = "encrypted.NewKeyring"
+	kr := &Keyring{primary: primary, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || len(id) > maxKeyIDLen {
+			return nil, errors.E(op, fmt.Errorf("key ID %q must be 1 to %d bytes long", id, maxKeyIDLen))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.E(op, fmt.Errorf("key %q: %s", id, err))
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.E(op, fmt.Errorf("key %q: %s", id, err))
+		}
+		kr.keys[id] = aead
+	}
+	if _, ok := kr.keys[primary]; !ok {
+		return nil, errors.E(op, fmt.Errorf("primary key %q is not in the keyring", primary))
+	}
+	return kr, nil
+}
+
+// ReadKeyring reads a Keyring from a JSON file
+// that holds the keys in base64 by their ID
+func ReadKeyring(path string) (*Keyring, error) {
+	const op errors.Op = "encrypted.ReadKeyring"
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var f keyringFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, errors.E(op, fmt.Errorf("%s: %s", path, err))
+	}
+	keys := make(map[string][]byte, len(f.Keys))
+	for id, encoded := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.E(op, fmt.Errorf("%s: key %q: %s", path, id, err))
+		}
+		keys[id] = key
+	}
+	kr, err := NewKeyring(f.Primary, keys)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return kr, nil
+}
@@ -0,0 +1,30 @@
+package encrypted
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// The zip is encrypted as the wrapped backend reads it.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "encrypted.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	sealedMod, err := s.seal(module, version, "mod", mod)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	sealedInfo, err := s.seal(module, version, "info", info)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	err = s.Backend.Save(ctx, module, version, sealedMod, s.sealZip(module, version, zip), sealedInfo)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
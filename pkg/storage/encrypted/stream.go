@@ -0,0 +1,288 @@
+package encrypted
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// streamVersion is the first byte of zips, which are encrypted in
+// chunks so that they never have to be held in memory. It is followed
+// by the length of the key ID and the key ID. Then comes every chunk
+// as its nonce and the sealed chunk. All chunks but the last hold
+// chunkSize bytes of the zip.
+const streamVersion = 2
+
+// chunkSize is the size of the chunks that zips are encrypted in
+const chunkSize = 64 * 1024
+
+// chunkAdditionalData binds a chunk to its file, its position and
+// to whether it is the last one, so that chunks can neither be
+// reordered nor dropped from the end without failing to decrypt
+func chunkAdditionalData(ad []byte, index uint64, last bool) []byte {
+	b := make([]byte, len(ad), len(ad)+9)
+	copy(b, ad)
+	b = append(b, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(b[len(ad):], index)
+	if last {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// readChunk reads up to len(buf) bytes of r into buf.
+// Fewer bytes are only returned at the end of r.
+func readChunk(r io.Reader, buf []byte) ([]byte, error) {
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return buf[:n], err
+}
+
+// sealingReader reads a zip from src and encrypts it chunk by chunk
+// as it is read. A chunk is read ahead to tell the last one.
+type sealingReader struct {
+	src   io.Reader
+	aead  cipher.AEAD
+	ad    []byte
+	index uint64
+
+	started bool
+	last    bool
+	chunk   []byte
+	spare   []byte
+	out     []byte
+	err     error
+}
+
+// sealZip returns a reader of zip encrypted with the primary key
+func (s *Storage) sealZip(module, version string, zip io.Reader) io.Reader {
+	id := s.keys.primary
+	header := append([]byte{streamVersion, byte(len(id))}, id...)
+	return &sealingReader{
+		src:  zip,
+		aead: s.keys.keys[id],
+		ad:   additionalData(module, version, "zip"),
+		out:  header,
+	}
+}
+
+func (r *sealingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.sealNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// sealNext encrypts the next chunk into out
+func (r *sealingReader) sealNext() error {
+	if r.last {
+		return io.EOF
+	}
+	var err error
+	if !r.started {
+		r.started = true
+		if r.chunk, err = readChunk(r.src, make([]byte, chunkSize)); err != nil {
+			return err
+		}
+	}
+	// an empty zip is a single empty chunk, and a chunk
+	// that is full is the last one if nothing follows it
+	var next []byte
+	r.last = len(r.chunk) < chunkSize
+	if !r.last {
+		if r.spare == nil {
+			r.spare = make([]byte, chunkSize)
+		}
+		if next, err = readChunk(r.src, r.spare); err != nil {
+			return err
+		}
+		r.last = len(next) == 0
+	}
+	nonce := make([]byte, r.aead.NonceSize(), r.aead.NonceSize()+len(r.chunk)+r.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	r.out = r.aead.Seal(nonce, nonce, r.chunk, chunkAdditionalData(r.ad, r.index, r.last))
+	r.index++
+	r.spare, r.chunk = r.chunk[:cap(r.chunk)], next
+	return nil
+}
+
+// openingReader decrypts a zip chunk by chunk as it is read
+type openingReader struct {
+	src    *bufio.Reader
+	closer io.Closer
+	aead   cipher.AEAD
+	ad     []byte
+	keyID  string
+	index  uint64
+	// pos is the offset in the zip of the next byte read
+	pos int64
+
+	last bool
+	buf  []byte
+	out  []byte
+	err  error
+}
+
+// openZip returns a reader that decrypts the zip of module@version
+// in rc as it is read. rc is left to the caller to close on errors.
+func (s *Storage) openZip(module, version string, rc io.ReadCloser) (io.ReadCloser, error) {
+	src := bufio.NewReader(rc)
+	header, err := src.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(header) < 2 || header[0] != streamVersion {
+		return nil, fmt.Errorf("zip file is not encrypted")
+	}
+	headerLen := 2 + int(header[1])
+	header, err = src.Peek(headerLen)
+	if err != nil {
+		return nil, fmt.Errorf("zip file is truncated")
+	}
+	id := string(header[2:])
+	aead, ok := s.keys.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("zip file is encrypted with key %q, which is not in the keyring", id)
+	}
+	src.Discard(headerLen)
+	r := &openingReader{
+		src:    src,
+		closer: rc,
+		aead:   aead,
+		ad:     additionalData(module, version, "zip"),
+		keyID:  id,
+		buf:    make([]byte, aead.NonceSize()+chunkSize+aead.Overhead()),
+	}
+	// a zip of another version or with another key fails right away
+	if err := r.openNext(); err != nil {
+		return nil, err
+	}
+	rs, ok := rc.(storage.SizeReadSeekCloser)
+	if !ok {
+		return r, nil
+	}
+	size, err := r.size(rs.Size() - int64(headerLen))
+	if err != nil {
+		return nil, err
+	}
+	return &seekableOpeningReader{openingReader: r, rs: rs, headerLen: int64(headerLen), size: size}, nil
+}
+
+func (r *openingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.openNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	r.pos += int64(n)
+	return n, nil
+}
+
+// openNext decrypts the next chunk into out
+func (r *openingReader) openNext() error {
+	const op errors.Op = "encrypted.openNext"
+	if r.last {
+		return io.EOF
+	}
+	sealed, err := readChunk(r.src, r.buf)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.last = len(sealed) < len(r.buf)
+	if !r.last {
+		if _, err := r.src.Peek(1); err == io.EOF {
+			r.last = true
+		} else if err != nil {
+			return errors.E(op, err)
+		}
+	}
+	nonceSize := r.aead.NonceSize()
+	if len(sealed) < nonceSize+r.aead.Overhead() {
+		return errors.E(op, "zip file is truncated")
+	}
+	nonce, sealed := sealed[:nonceSize], sealed[nonceSize:]
+	r.out, err = r.aead.Open(sealed[:0], nonce, sealed, chunkAdditionalData(r.ad, r.index, r.last))
+	if err != nil {
+		return errors.E(op, fmt.Errorf("zip file can not be decrypted with key %q: %s", r.keyID, err))
+	}
+	r.index++
+	return nil
+}
+
+// size returns the size of a zip that is sealedSize bytes encrypted
+func (r *openingReader) size(sealedSize int64) (int64, error) {
+	sealedChunk := int64(len(r.buf))
+	chunks, rest := sealedSize/sealedChunk, sealedSize%sealedChunk
+	size := chunks * chunkSize
+	if rest == 0 && chunks > 0 {
+		return size, nil
+	}
+	if rest < int64(r.aead.NonceSize()+r.aead.Overhead()) {
+		return 0, fmt.Errorf("zip file is truncated")
+	}
+	return size + rest - int64(r.aead.NonceSize()+r.aead.Overhead()), nil
+}
+
+func (r *openingReader) Close() error {
+	return r.closer.Close()
+}
+
+// seekableOpeningReader is an openingReader of a zip whose
+// encrypted form is a storage.SizeReadSeekCloser. It seeks
+// to the chunk that holds an offset and decrypts it.
+type seekableOpeningReader struct {
+	*openingReader
+	rs        storage.SizeReadSeekCloser
+	headerLen int64
+	size      int64
+}
+
+func (s *seekableOpeningReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += s.pos
+	case io.SeekEnd:
+		offset += s.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek to negative offset %d", offset)
+	}
+	s.pos, s.out = offset, nil
+	if offset >= s.size {
+		s.last, s.err = true, io.EOF
+		return offset, nil
+	}
+	chunk := offset / chunkSize
+	if _, err := s.rs.Seek(s.headerLen+chunk*int64(len(s.buf)), io.SeekStart); err != nil {
+		return 0, err
+	}
+	s.src.Reset(s.rs)
+	s.index, s.last = uint64(chunk), false
+	if s.err = s.openNext(); s.err != nil {
+		return 0, s.err
+	}
+	s.out = s.out[offset-chunk*chunkSize:]
+	return offset, nil
+}
+
+func (s *seekableOpeningReader) Size() int64 {
+	return s.size
+}
@@ -0,0 +1,20 @@
+package encrypted
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface if
+// the wrapped backend implements it. The responses of checksum
+// databases are public and saved as they are.
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	return storage.SaveSumDB(ctx, s.Backend, path, content)
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+// if the wrapped backend implements it
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	return storage.SumDB(ctx, s.Backend, path)
+}
@@ -0,0 +1,29 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// Both backends resume their catalog after the module version a
+// token refers to, so a page of each is merged into a page of both.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "fallback.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	primary, pnext, err := s.primary.Catalog(ctx, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	fallback, fnext, err := s.fallback.Catalog(ctx, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	page, next := storage.MergeCatalogPages([][]paths.AllPathParams{primary, fallback}, pnext != "" || fnext != "", pageSize)
+	return page, next, nil
+}
@@ -0,0 +1,27 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Exists implements the (./pkg/storage).Checker interface
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "fallback.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.primary.Exists(ctx, module, version)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if exists {
+		return true, nil
+	}
+	exists, err = s.fallback.Exists(ctx, module, version)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return exists, nil
+}
@@ -0,0 +1,35 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "fallback.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.primary.SaveChecksum(ctx, module, version, sum); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "fallback.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	sum, err := s.primary.Checksum(ctx, module, version)
+	if isNotFound(err) {
+		sum, err = s.fallback.Checksum(ctx, module, version)
+	}
+	if err != nil {
+		return sum, errors.E(op, err)
+	}
+	return sum, nil
+}
@@ -0,0 +1,29 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface.
+// The version is deleted from both backends, so that
+// the fallback backend does not serve it again.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "fallback.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	perr := s.primary.Delete(ctx, module, version)
+	if perr != nil && !isNotFound(perr) {
+		return errors.E(op, perr)
+	}
+	ferr := s.fallback.Delete(ctx, module, version)
+	if ferr != nil && !isNotFound(ferr) {
+		return errors.E(op, ferr)
+	}
+	if perr != nil && ferr != nil {
+		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Package fallback provides a storage backend for the cutover from one
+// storage backend to another, e.g. while the module versions of the old
+// one are being migrated.
+//
+// All writes go to the new, primary backend. Reads are served by the
+// primary backend and by the old, fallback backend for the versions it
+// does not have yet. Lists and the catalog are those of both backends.
+package fallback
+
+import (
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	primary  storage.Backend
+	fallback storage.Backend
+}
+
+// New returns a Storage that writes to primary and
+// reads from fallback what primary does not have
+func New(primary, fallback storage.Backend) *Storage {
+	return &Storage{primary: primary, fallback: fallback}
+}
+
+func isNotFound(err error) bool {
+	return errors.Kind(err) == errors.KindNotFound
+}
@@ -0,0 +1,138 @@
+package fallback_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/gomods/athens/pkg/storage/fallback"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const module = "github.com/athens-artifacts/fallback"
+
+func newBackend(t *testing.T) storage.Backend {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return b
+}
+
+func save(t *testing.T, b storage.Backend, module, version string) {
+	t.Helper()
+	err := b.Save(context.Background(), module, version, []byte("module "+module), bytes.NewReader([]byte(version)), []byte("{}"))
+	require.NoError(t, err)
+}
+
+// testSuite runs the compliance tests against
+// a primary and a fallback backend in memory
+type testSuite struct {
+	t       *testing.T
+	storage *fallback.Storage
+}
+
+func (ts *testSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+func (ts *testSuite) StorageHumanReadableName() string {
+	return "Fallback"
+}
+
+func (ts *testSuite) Cleanup() error {
+	*ts.storage = *fallback.New(newBackend(ts.t), newBackend(ts.t))
+	return nil
+}
+
+func TestBackendCompliance(t *testing.T) {
+	ts := &testSuite{t: t, storage: fallback.New(newBackend(t), newBackend(t))}
+	compliance.RunTests(t, ts)
+}
+
+func TestReadsFromFallback(t *testing.T) {
+	ctx := context.Background()
+	primary, old := newBackend(t), newBackend(t)
+	s := fallback.New(primary, old)
+	save(t, old, module, "v1.0.0")
+	require.NoError(t, old.SaveChecksum(ctx, module, "v1.0.0", storage.Checksum{Sum: "h1:old"}))
+	save(t, s, module, "v1.1.0")
+
+	exists, err := primary.Exists(ctx, module, "v1.1.0")
+	require.NoError(t, err)
+	require.True(t, exists, "saves go to the primary backend")
+
+	zip, err := s.Zip(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zip)
+	zip.Close()
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1.0.0"), b)
+	sum, err := s.Checksum(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "h1:old", sum.Sum)
+
+	versions, err := s.List(ctx, module)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0"}, versions)
+
+	require.NoError(t, s.Delete(ctx, module, "v1.0.0"))
+	_, err = s.Info(ctx, module, "v1.0.0")
+	require.Equal(t, errors.KindNotFound, errors.Kind(err))
+}
+
+func TestCatalogMerge(t *testing.T) {
+	ctx := context.Background()
+	primary, old := newBackend(t), newBackend(t)
+	s := fallback.New(primary, old)
+	for _, v := range []string{"v1.0.0", "v1.2.0", "v1.4.0"} {
+		save(t, primary, module, v)
+	}
+	for _, v := range []string{"v1.1.0", "v1.2.0", "v1.3.0", "v1.5.0"} {
+		save(t, old, module, v)
+	}
+
+	var all []paths.AllPathParams
+	token := ""
+	for {
+		page, next, err := s.Catalog(ctx, token, 2)
+		require.NoError(t, err)
+		all = append(all, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	var versions []string
+	for _, p := range all {
+		versions = append(versions, p.Version)
+	}
+	require.Equal(t, []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0", "v1.4.0", "v1.5.0"}, versions)
+}
+
+func TestSumDBFromFallback(t *testing.T) {
+	ctx := context.Background()
+	primary, old := newBackend(t), newBackend(t)
+	s := fallback.New(primary, old)
+	const lookup = "sum.golang.org/lookup/" + module + "@v1.0.0"
+	require.NoError(t, storage.SaveSumDB(ctx, old, lookup, []byte("record")))
+	content, err := s.SumDB(ctx, lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte("record"), content)
+
+	// saves go to primary only
+	require.NoError(t, s.SaveSumDB(ctx, lookup, []byte("new record")))
+	content, err = storage.SumDB(ctx, primary, lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new record"), content)
+	content, err = storage.SumDB(ctx, old, lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte("record"), content)
+}
@@ -0,0 +1,54 @@
+package fallback
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "fallback.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	info, err := s.primary.Info(ctx, module, version)
+	if isNotFound(err) {
+		info, err = s.fallback.Info(ctx, module, version)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "fallback.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	mod, err := s.primary.GoMod(ctx, module, version)
+	if isNotFound(err) {
+		mod, err = s.fallback.GoMod(ctx, module, version)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "fallback.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	zip, err := s.primary.Zip(ctx, module, version)
+	if isNotFound(err) {
+		zip, err = s.fallback.Zip(ctx, module, version)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return zip, nil
+}
@@ -0,0 +1,35 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+)
+
+// List implements the (./pkg/storage).Lister interface.
+// It lists the versions of both backends.
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "fallback.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	primary, err := s.primary.List(ctx, module)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	fallback, err := s.fallback.List(ctx, module)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	seen := make(map[string]bool, len(primary))
+	versions := make([]string, 0, len(primary)+len(fallback))
+	for _, v := range append(primary, fallback...) {
+		if !seen[v] {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
@@ -0,0 +1,35 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "fallback.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.primary.SaveMetadata(ctx, module, version, meta); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Stat implements the (./pkg/storage).Statter interface
+func (s *Storage) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "fallback.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	meta, err := s.primary.Stat(ctx, module, version)
+	if isNotFound(err) {
+		meta, err = s.fallback.Stat(ctx, module, version)
+	}
+	if err != nil {
+		return meta, errors.E(op, err)
+	}
+	return meta, nil
+}
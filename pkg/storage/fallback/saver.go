@@ -0,0 +1,21 @@
+package fallback
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// Versions are only ever saved to the primary backend.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "fallback.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.primary.Save(ctx, module, version, mod, zip, info); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
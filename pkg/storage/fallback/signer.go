@@ -0,0 +1,27 @@
+package fallback
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SignedURL implements the (./pkg/storage).Signer interface. Versions
+// that primary does not have are signed by fallback, so both need to
+// implement it to redirect to every version.
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "fallback.SignedURL"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	u, err := storage.SignedURL(ctx, s.primary, module, version, ext, expiry)
+	if isNotFound(err) {
+		u, err = storage.SignedURL(ctx, s.fallback, module, version, ext, expiry)
+	}
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return u, nil
+}
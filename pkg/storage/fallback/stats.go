@@ -0,0 +1,12 @@
+package fallback
+
+import (
+	"github.com/gomods/athens/pkg/storage/quota"
+)
+
+// Stats implements the (./pkg/storage/quota).Statser interface if
+// primary implements it. It returns the usage of primary, the
+// fallback backend only shrinks as versions are migrated off it.
+func (s *Storage) Stats() (quota.Stats, error) {
+	return quota.StatsOf(s.primary)
+}
@@ -0,0 +1,38 @@
+package fallback
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher
+// interface if primary implements it
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "fallback.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := storage.SaveSumDB(ctx, s.primary, path, content); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface. Responses
+// that primary does not have are read from fallback, if either of them
+// implements it.
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "fallback.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	content, err := storage.SumDB(ctx, s.primary, path)
+	if isNotFound(err) || errors.Kind(err) == errors.KindNotImplemented {
+		content, err = storage.SumDB(ctx, s.fallback, path)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return content, nil
+}
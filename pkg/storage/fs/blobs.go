@@ -0,0 +1,124 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// Blobs implements the (./pkg/storage/cas).Blobs interface
+// and stores every key as a file below rootDir
+type Blobs struct {
+	rootDir    string
+	filesystem afero.Fs
+}
+
+// NewBlobs returns a Blobs that stores everything under rootDir.
+// If the root directory does not exist an error is returned
+func NewBlobs(rootDir string, filesystem afero.Fs) (*Blobs, error) {
+	const op errors.Op = "fs.NewBlobs"
+	exists, err := afero.Exists(filesystem, rootDir)
+	if err != nil {
+		return nil, errors.E(op, fmt.Errorf("could not check if root directory `%s` exists: %s", rootDir, err))
+	}
+	if !exists {
+		return nil, errors.E(op, fmt.Errorf("root directory `%s` does not exist", rootDir))
+	}
+	return &Blobs{rootDir: rootDir, filesystem: filesystem}, nil
+}
+
+func (b *Blobs) location(key string) string {
+	return filepath.Join(b.rootDir, filepath.FromSlash(key))
+}
+
+// Open returns the content of the file of key
+func (b *Blobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op errors.Op = "fs.Blobs.Open"
+	f, err := b.filesystem.Open(b.location(key))
+	if os.IsNotExist(err) {
+		return nil, errors.E(op, errors.M(key), errors.KindNotFound)
+	}
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(key))
+	}
+	return f, nil
+}
+
+// Put writes content to a temporary file and renames it in place
+func (b *Blobs) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	const op errors.Op = "fs.Blobs.Put"
+	path := b.location(key)
+	dir := filepath.Dir(path)
+	if err := b.filesystem.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	f, err := afero.TempFile(b.filesystem, dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	_, err = io.Copy(f, content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = b.filesystem.Rename(f.Name(), path)
+	}
+	if err != nil {
+		b.filesystem.Remove(f.Name())
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// Exists returns true if the file of key exists
+func (b *Blobs) Exists(ctx context.Context, key string) (bool, error) {
+	const op errors.Op = "fs.Blobs.Exists"
+	exists, err := afero.Exists(b.filesystem, b.location(key))
+	if err != nil {
+		return false, errors.E(op, err, errors.M(key))
+	}
+	return exists, nil
+}
+
+// Delete removes the file of key
+func (b *Blobs) Delete(ctx context.Context, key string) error {
+	const op errors.Op = "fs.Blobs.Delete"
+	err := b.filesystem.Remove(b.location(key))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// List walks the directory of prefix and returns the keys of
+// all files in it, leaving out the temporary files of Put
+func (b *Blobs) List(ctx context.Context, prefix string) ([]string, error) {
+	const op errors.Op = "fs.Blobs.List"
+	keys := []string{}
+	err := afero.Walk(b.filesystem, b.location(prefix), func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Base(path)[0] == '.' {
+			return nil
+		}
+		rel, err := filepath.Rel(b.rootDir, path)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(prefix))
+	}
+	return keys, nil
+}
@@ -0,0 +1,126 @@
+package fs
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It reads the directories in the order of the catalog, skips
+// those that hold only versions before token and stops once
+// the page is complete.
+func (s *storageImpl) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "fs.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	from, err := storage.ParseCatalogToken(token)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	if pageSize <= 0 {
+		return nil, "", errors.E(op, "page size must be positive", errors.KindBadRequest)
+	}
+	// one more than a page tells if there is a next page
+	c := &catalogWalk{filesystem: s.filesystem, from: from, after: token != "", limit: pageSize + 1}
+	if err := c.modulesBelow(s.rootDir, ""); err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	if len(c.found) <= pageSize {
+		return c.found, "", nil
+	}
+	page := c.found[:pageSize]
+	return page, storage.CatalogToken(page[pageSize-1]), nil
+}
+
+// catalogWalk collects the module versions after from,
+// in the order of the catalog, until it has limit of them
+type catalogWalk struct {
+	filesystem afero.Fs
+	from       paths.AllPathParams
+	after      bool
+	limit      int
+	found      []paths.AllPathParams
+}
+
+// modulesBelow collects the versions of the modules below module, which
+// is stored in dir. A module precedes the modules below it but not all of
+// the modules next to it: example.com/a < example.com/a-b < example.com/a/b.
+// So every directory in dir is visited twice, for its module in the order
+// of its name and for the modules below it in the order of its name and "/".
+func (c *catalogWalk) modulesBelow(dir, module string) error {
+	fileInfos, err := afero.ReadDir(c.filesystem, dir)
+	if err != nil {
+		return err
+	}
+	type visit struct {
+		key   string
+		name  string
+		below bool
+	}
+	var visits []visit
+	for _, fi := range fileInfos {
+		// no element of a module path starts with a dot,
+		// unlike the directory of checksum database responses
+		if fi.IsDir() && !strings.HasPrefix(fi.Name(), ".") {
+			visits = append(visits, visit{fi.Name(), fi.Name(), false}, visit{fi.Name() + "/", fi.Name(), true})
+		}
+	}
+	sort.Slice(visits, func(i, j int) bool { return visits[i].key < visits[j].key })
+	for _, v := range visits {
+		if len(c.found) >= c.limit {
+			return nil
+		}
+		sub := path.Join(module, v.name)
+		if v.below {
+			// the modules below sub all sort before the token
+			if c.after && sub+"/" < c.from.Module && !strings.HasPrefix(c.from.Module, sub+"/") {
+				continue
+			}
+			err = c.modulesBelow(filepath.Join(dir, v.name), sub)
+		} else {
+			if c.after && sub < c.from.Module {
+				continue
+			}
+			err = c.versions(filepath.Join(dir, v.name), sub)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// versions collects the versions of module, which is stored in dir
+func (c *catalogWalk) versions(dir, module string) error {
+	fileInfos, err := afero.ReadDir(c.filesystem, dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fileInfos {
+		if len(c.found) >= c.limit {
+			return nil
+		}
+		version := fi.Name()
+		if !fi.IsDir() || (c.after && module == c.from.Module && version <= c.from.Version) {
+			continue
+		}
+		// the .info file marks a complete version, see Save
+		exists, err := afero.Exists(c.filesystem, filepath.Join(dir, version, version+".info"))
+		if err != nil {
+			return err
+		}
+		if exists {
+			c.found = append(c.found, paths.AllPathParams{Module: module, Version: version})
+		}
+	}
+	return nil
+}
@@ -14,7 +14,8 @@ func (v *storageImpl) Exists(ctx context.Context, module, version string) (bool,
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
 	versionedPath := v.versionLocation(module, version)
-	exists, err := afero.Exists(v.filesystem, filepath.Join(versionedPath, "go.mod"))
+	// the .info file is written last by Save and marks a complete version
+	exists, err := afero.Exists(v.filesystem, filepath.Join(versionedPath, version+".info"))
 	if err != nil {
 		return false, errors.E(op, errors.M(module), errors.V(version), err)
 	}
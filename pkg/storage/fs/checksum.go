@@ -0,0 +1,59 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+const checksumFile = "checksum.json"
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *storageImpl) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "fs.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	dir := s.versionLocation(module, version)
+	if err := s.filesystem.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	b, err := json.Marshal(sum)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	tmp, err := s.stage(dir, checksumFile, bytes.NewReader(b))
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.filesystem.Rename(tmp, filepath.Join(dir, checksumFile)); err != nil {
+		s.filesystem.Remove(tmp)
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *storageImpl) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "fs.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	b, err := afero.ReadFile(s.filesystem, filepath.Join(s.versionLocation(module, version), checksumFile))
+	if os.IsNotExist(err) {
+		return sum, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := json.Unmarshal(b, &sum); err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return sum, nil
+}
@@ -0,0 +1,54 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/cas"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
+
+// quotaTestSuite runs the compliance tests against a storage with a quota
+type quotaTestSuite struct {
+	storage.TestSuite
+	quota *quota.Storage
+}
+
+func (ts *quotaTestSuite) Storage() storage.Backend {
+	return ts.quota
+}
+
+func TestQuotaCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	q := quota.New(ts.Storage(), 1024*1024*1024, nil, nil)
+	compliance.RunTests(t, &quotaTestSuite{ts, q})
+}
+
+// casTestSuite runs the compliance tests against
+// the content-addressed layout in the same root directory
+type casTestSuite struct {
+	*TestSuite
+	cas *cas.Storage
+}
+
+func (ts *casTestSuite) Storage() storage.Backend {
+	return ts.cas
+}
+
+func TestContentAddressedCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	fsTests := ts.(*TestSuite)
+	blobs, err := NewBlobs(fsTests.rootDir, fsTests.fs)
+	require.NoError(t, err)
+	compliance.RunTests(t, &casTestSuite{fsTests, cas.New(blobs)})
+}
@@ -2,6 +2,7 @@ package fs
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
@@ -20,5 +21,10 @@ func (v *storageImpl) Delete(ctx context.Context, module, version string) error
 	if !exists {
 		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
 	}
+	// remove the commit marker first so that
+	// the version disappears as a whole
+	if err := v.filesystem.Remove(filepath.Join(versionedPath, version+".info")); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
 	return v.filesystem.RemoveAll(versionedPath)
 }
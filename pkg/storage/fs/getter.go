@@ -28,6 +28,13 @@ func (v *storageImpl) GoMod(ctx context.Context, module, version string) ([]byte
 	const op errors.Op = "fs.GoMod"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := v.Exists(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
 	versionedPath := v.versionLocation(module, version)
 	mod, err := afero.ReadFile(v.filesystem, filepath.Join(versionedPath, "go.mod"))
 	if err != nil {
@@ -41,12 +48,34 @@ func (v *storageImpl) Zip(ctx context.Context, module, version string) (io.ReadC
 	const op errors.Op = "fs.Zip"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := v.Exists(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
 	versionedPath := v.versionLocation(module, version)
 
 	src, err := v.filesystem.OpenFile(filepath.Join(versionedPath, "source.zip"), os.O_RDONLY, 0666)
 	if err != nil {
 		return nil, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
 	}
+	fi, err := src.Stat()
+	if err != nil {
+		src.Close()
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	return &zipFile{File: src, size: fi.Size()}, nil
+}
+
+// zipFile is a stored zip that implements storage.SizeReadSeekCloser
+type zipFile struct {
+	afero.File
+	size int64
+}
 
-	return src, nil
+func (z *zipFile) Size() int64 {
+	return z.size
 }
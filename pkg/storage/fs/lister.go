@@ -3,9 +3,11 @@ package fs
 import (
 	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
 	"github.com/spf13/afero"
 )
 
@@ -24,9 +26,20 @@ func (l *storageImpl) List(ctx context.Context, module string) ([]string, error)
 	}
 	ret := []string{}
 	for _, fileInfo := range fileInfos {
-		if fileInfo.IsDir() {
-			ret = append(ret, fileInfo.Name())
+		if !fileInfo.IsDir() {
+			continue
+		}
+		// the module directory also holds the directories of nested
+		// modules (e.g. /v2), only those with an .info file are versions
+		version := fileInfo.Name()
+		exists, err := afero.Exists(l.filesystem, filepath.Join(loc, version, version+".info"))
+		if err != nil {
+			return nil, errors.E(op, errors.M(module), err, errors.KindUnexpected)
+		}
+		if exists {
+			ret = append(ret, version)
 		}
 	}
+	semver.Sort(ret)
 	return ret, nil
 }
@@ -0,0 +1,59 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/spf13/afero"
+)
+
+const metadataFile = "metadata.json"
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *storageImpl) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "fs.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	dir := s.versionLocation(module, version)
+	if err := s.filesystem.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	tmp, err := s.stage(dir, metadataFile, bytes.NewReader(b))
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := s.filesystem.Rename(tmp, filepath.Join(dir, metadataFile)); err != nil {
+		s.filesystem.Remove(tmp)
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Stat implements the (./pkg/storage).Statter interface
+func (s *storageImpl) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "fs.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var meta storage.Metadata
+	b, err := afero.ReadFile(s.filesystem, filepath.Join(s.versionLocation(module, version), metadataFile))
+	if os.IsNotExist(err) {
+		return meta, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	if err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return meta, nil
+}
@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
@@ -11,6 +12,10 @@ import (
 	"github.com/spf13/afero"
 )
 
+// Save stores the module files in the versioned directory.
+// Every file is first written under a temporary name and then renamed
+// in place, the .info file last. The .info file is the commit marker
+// of a version, so a crashed or failed Save leaves no visible version.
 func (s *storageImpl) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
 	const op errors.Op = "fs.Save"
 	ctx, span := observ.StartSpan(ctx, op.String())
@@ -23,26 +28,59 @@ func (s *storageImpl) Save(ctx context.Context, module, version string, mod []by
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
 
-	// write the go.mod file
-	if err := afero.WriteFile(s.filesystem, filepath.Join(dir, "go.mod"), mod, os.ModePerm); err != nil {
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
 
-	// write the zipfile
-	f, err := s.filesystem.OpenFile(filepath.Join(dir, "source.zip"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-	if err != nil {
-		return errors.E(op, err, errors.M(module), errors.V(version))
+	files := []struct {
+		name    string
+		content io.Reader
+	}{
+		{name: "go.mod", content: bytes.NewReader(mod)},
+		{name: "source.zip", content: zip},
+		{name: version + ".info", content: bytes.NewReader(info)},
 	}
-	defer f.Close()
-	_, err = io.Copy(f, zip)
-	if err != nil {
-		return errors.E(op, err, errors.M(module), errors.V(version))
+	staged := make([]string, 0, len(files))
+	defer func() {
+		// only left over if the version was not committed
+		for _, tmp := range staged {
+			s.filesystem.Remove(tmp)
+		}
+	}()
+	for _, f := range files {
+		tmp, err := s.stage(dir, f.name, f.content)
+		if err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+		staged = append(staged, tmp)
 	}
 
-	// write the info file
-	err = afero.WriteFile(s.filesystem, filepath.Join(dir, version+".info"), info, os.ModePerm)
-	if err != nil {
-		return errors.E(op, err)
+	for len(staged) > 0 {
+		if err := s.filesystem.Rename(staged[0], filepath.Join(dir, files[0].name)); err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+		staged, files = staged[1:], files[1:]
 	}
 	return nil
 }
+
+// stage writes content to a new temporary file in dir and returns its path
+func (s *storageImpl) stage(dir, name string, content io.Reader) (string, error) {
+	f, err := afero.TempFile(s.filesystem, dir, "."+name+".tmp")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		s.filesystem.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
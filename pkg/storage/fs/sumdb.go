@@ -0,0 +1,56 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/spf13/afero"
+)
+
+// sumDBDir is the directory below the root that checksum database
+// responses are kept in. No module path starts with a dot.
+const sumDBDir = ".sumdb"
+
+func (s *storageImpl) sumDBLocation(path string) string {
+	return filepath.Join(s.rootDir, sumDBDir, filepath.FromSlash(path))
+}
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *storageImpl) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "fs.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	loc := s.sumDBLocation(path)
+	dir := filepath.Dir(loc)
+	if err := s.filesystem.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	tmp, err := s.stage(dir, filepath.Base(loc), bytes.NewReader(content))
+	if err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	if err := s.filesystem.Rename(tmp, loc); err != nil {
+		s.filesystem.Remove(tmp)
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *storageImpl) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "fs.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := afero.ReadFile(s.filesystem, s.sumDBLocation(path))
+	if os.IsNotExist(err) {
+		return nil, errors.E(op, errors.M(path), errors.KindNotFound)
+	}
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	return b, nil
+}
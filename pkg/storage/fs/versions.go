@@ -0,0 +1,61 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/spf13/afero"
+)
+
+// Versions returns the versions stored under rootDir, with the space
+// they use. It is meant to set up a quota for an existing storage.
+func Versions(rootDir string, filesystem afero.Fs) ([]quota.Version, error) {
+	const op errors.Op = "fs.Versions"
+	var versions []quota.Version
+	err := afero.Walk(filesystem, rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(path)
+		version := filepath.Base(dir)
+		// the .info file marks a complete version, see Save
+		if info.IsDir() || info.Name() != version+".info" {
+			return nil
+		}
+		module, err := filepath.Rel(rootDir, filepath.Dir(dir))
+		if err != nil {
+			return err
+		}
+		size, err := dirSize(filesystem, dir)
+		if err != nil {
+			return err
+		}
+		versions = append(versions, quota.Version{
+			Module:   filepath.ToSlash(module),
+			Version:  version,
+			Size:     size,
+			LastUsed: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return versions, nil
+}
+
+func dirSize(filesystem afero.Fs, dir string) (int64, error) {
+	fileInfos, err := afero.ReadDir(filesystem, dir)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, fi := range fileInfos {
+		if !fi.IsDir() {
+			size += fi.Size()
+		}
+	}
+	return size, nil
+}
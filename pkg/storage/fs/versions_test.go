@@ -0,0 +1,34 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions(t *testing.T) {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	s, err := NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, s.Save(ctx, "github.com/a/b", "v1.0.0", []byte("mod"), bytes.NewReader([]byte("zip")), []byte("info")))
+	require.NoError(t, s.Save(ctx, "github.com/a/b/v2", "v2.0.0", []byte("mod"), bytes.NewReader([]byte("zip!")), []byte("info")))
+	// an incomplete version is not reported
+	require.NoError(t, afero.WriteFile(memFs, "/athens/github.com/a/c/v1.0.0/go.mod", []byte("mod"), 0644))
+
+	versions, err := Versions("/athens", memFs)
+	require.NoError(t, err)
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Module < versions[j].Module })
+	require.Len(t, versions, 2)
+	require.Equal(t, "github.com/a/b", versions[0].Module)
+	require.Equal(t, "v1.0.0", versions[0].Version)
+	require.Equal(t, int64(10), versions[0].Size)
+	require.Equal(t, "github.com/a/b/v2", versions[1].Module)
+	require.Equal(t, "v2.0.0", versions[1].Version)
+	require.Equal(t, int64(11), versions[1].Size)
+}
@@ -0,0 +1,97 @@
+package gcp
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// Blobs implements the (./pkg/storage/cas).Blobs interface
+// and stores every key as an object in a bucket
+type Blobs struct {
+	bucket       Bucket
+	closeStorage func() error
+}
+
+// NewBlobs returns a Blobs that stores everything in the bucket of gcpConf.
+// Credentials are found the same way as by New.
+func NewBlobs(ctx context.Context, gcpConf *config.GCPConfig) (*Blobs, error) {
+	const op errors.Op = "gcp.NewBlobs"
+	bkt, closeStorage, err := newBucket(ctx, gcpConf)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &Blobs{bucket: bkt, closeStorage: closeStorage}, nil
+}
+
+// Open returns a reader for the object of key
+func (b *Blobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op errors.Op = "gcp.Blobs.Open"
+	rc, err := b.bucket.Open(ctx, key)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(key))
+	}
+	return rc, nil
+}
+
+// Put uploads content as the object of key. Objects are only
+// replaced once their upload completes, so an upload that fails
+// is canceled and leaves the previous object, if any, in place.
+func (b *Blobs) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	const op errors.Op = "gcp.Blobs.Put"
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wc := b.bucket.Write(ctx, key)
+	if _, err := io.Copy(wc, content); err != nil {
+		cancel()
+		wc.Close()
+		return errors.E(op, err, errors.M(key))
+	}
+	if err := wc.Close(); err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// Exists returns true if the object of key exists
+func (b *Blobs) Exists(ctx context.Context, key string) (bool, error) {
+	const op errors.Op = "gcp.Blobs.Exists"
+	exists, err := b.bucket.Exists(ctx, key)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(key))
+	}
+	return exists, nil
+}
+
+// Delete removes the object of key, if it exists
+func (b *Blobs) Delete(ctx context.Context, key string) error {
+	const op errors.Op = "gcp.Blobs.Delete"
+	exists, err := b.bucket.Exists(ctx, key)
+	if err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	if !exists {
+		return nil
+	}
+	if err := b.bucket.Delete(ctx, key); err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// List returns the names of all objects that start with prefix
+func (b *Blobs) List(ctx context.Context, prefix string) ([]string, error) {
+	const op errors.Op = "gcp.Blobs.List"
+	keys, err := b.bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(prefix))
+	}
+	return keys, nil
+}
+
+// Close calls the underlying storage client's close method
+func (b *Blobs) Close() error {
+	return b.closeStorage()
+}
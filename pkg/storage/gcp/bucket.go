@@ -9,13 +9,21 @@ import (
 type Bucket interface {
 	// Delete removes the file
 	Delete(ctx context.Context, path string) error
-	// Open returns a reader for a path and any error
+	// Open returns a reader for a path and any error,
+	// which is an errors.KindNotFound error if the file does not exist
 	Open(ctx context.Context, path string) (io.ReadCloser, error)
 	// Write returns a new writer for a path
 	// This writer will overwrite any existing file stored at the same path
 	Write(ctx context.Context, path string) io.WriteCloser
 	// List returns a slice of paths for a prefix and any error
 	List(ctx context.Context, prefix string) ([]string, error)
+	// Walk calls fn with the paths for a prefix in lexical
+	// order until fn returns false or all paths were passed
+	Walk(ctx context.Context, prefix string, fn func(path string) bool) error
+	// WalkDirs calls fn with the directories right below a prefix,
+	// the prefixes of the paths up to and including the next "/",
+	// in lexical order until fn returns false or all were passed
+	WalkDirs(ctx context.Context, prefix string, fn func(dir string) bool) error
 	// Exists returns true if the file exists
 	Exists(ctx context.Context, path string) (bool, error)
 }
@@ -27,6 +27,9 @@ func (b *gcpBucket) Delete(ctx context.Context, path string) error {
 func (b *gcpBucket) Open(ctx context.Context, path string) (io.ReadCloser, error) {
 	const op errors.Op = "gcpBucket.Open"
 	rc, err := b.Object(path).NewReader(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, errors.E(op, err, errors.M(path), errors.KindNotFound)
+	}
 	if err != nil {
 		return rc, errors.E(op, err, errors.M(path))
 	}
@@ -56,6 +59,41 @@ func (b *gcpBucket) List(ctx context.Context, prefix string) ([]string, error) {
 	return res, nil
 }
 
+func (b *gcpBucket) Walk(ctx context.Context, prefix string, fn func(path string) bool) error {
+	const op errors.Op = "gcpBucket.Walk"
+	it := b.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if !fn(attrs.Name) {
+			return nil
+		}
+	}
+}
+
+func (b *gcpBucket) WalkDirs(ctx context.Context, prefix string, fn func(dir string) bool) error {
+	const op errors.Op = "gcpBucket.WalkDirs"
+	it := b.Objects(ctx, &storage.Query{Prefix: prefix, Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return errors.E(op, err)
+		}
+		// the objects right below prefix come without a Prefix
+		if attrs.Prefix != "" && !fn(attrs.Prefix) {
+			return nil
+		}
+	}
+}
+
 func (b *gcpBucket) Exists(ctx context.Context, path string) (bool, error) {
 	const op errors.Op = "gcpBucket.Exists"
 	_, err := b.Object(path).Attrs(ctx)
@@ -5,8 +5,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
+
+	"github.com/gomods/athens/pkg/errors"
 )
 
 type bucketMock struct {
@@ -14,6 +17,11 @@ type bucketMock struct {
 	lock           sync.RWMutex
 	readLockCount  int
 	writeLockCount int
+	// closeErr fails closing the writers of paths with this suffix,
+	// which then are not written, like objects in GCS
+	closeErr string
+	// walked counts the paths passed to Walk
+	walked int
 }
 
 func newBucketMock() *bucketMock {
@@ -40,7 +48,11 @@ func (r *bucketReader) Close() error {
 
 func (r *bucketWriter) Close() error {
 	r.bucketMock.writeLockCount--
-	r.bucketMock.lock.Unlock()
+	defer r.bucketMock.lock.Unlock()
+	if r.bucketMock.closeErr != "" && strings.HasSuffix(r.path, r.bucketMock.closeErr) {
+		delete(r.bucketMock.db, r.path)
+		return fmt.Errorf("writing %s failed", r.path)
+	}
 	return nil
 }
 
@@ -57,11 +69,12 @@ func (m *bucketMock) Delete(ctx context.Context, path string) error {
 }
 
 func (m *bucketMock) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	const op errors.Op = "bucketMock.Open"
 	m.lock.RLock()
 	data, ok := m.db[path]
 	if !ok {
 		m.lock.RUnlock()
-		return nil, fmt.Errorf("path %s not found", path)
+		return nil, errors.E(op, fmt.Errorf("path %s not found", path), errors.KindNotFound)
 	}
 	m.readLockCount++
 	r := bytes.NewReader(data)
@@ -88,6 +101,42 @@ func (m *bucketMock) List(ctx context.Context, prefix string) ([]string, error)
 	return res, nil
 }
 
+func (m *bucketMock) Walk(ctx context.Context, prefix string, fn func(path string) bool) error {
+	paths, _ := m.List(ctx, prefix)
+	sort.Strings(paths)
+	m.lock.Lock()
+	m.walked += len(paths)
+	m.lock.Unlock()
+	for _, p := range paths {
+		if !fn(p) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *bucketMock) WalkDirs(ctx context.Context, prefix string, fn func(dir string) bool) error {
+	paths, _ := m.List(ctx, prefix)
+	sort.Strings(paths)
+	var dirs []string
+	for _, p := range paths {
+		i := strings.Index(p[len(prefix):], "/")
+		if i < 0 {
+			continue
+		}
+		dir := p[:len(prefix)+i+1]
+		if len(dirs) == 0 || dirs[len(dirs)-1] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, dir := range dirs {
+		if !fn(dir) {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (m *bucketMock) Exists(ctx context.Context, path string) (bool, error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
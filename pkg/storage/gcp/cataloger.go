@@ -0,0 +1,25 @@
+package gcp
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It lists the directories that hold the module of token and
+// from there on only the paths that follow it.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "gcp.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	walk := storage.SkippingNameWalker(s.bucket.Walk, s.bucket.WalkDirs)
+	page, next, err := storage.CatalogFromNames(ctx, walk, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
@@ -0,0 +1,52 @@
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/paths"
+)
+
+func (g *GcpTests) TestCatalogSkipsToToken() {
+	r := g.Require()
+	bucket := newBucketMock()
+	store := newWithBucket(bucket, g.url, time.Second, &config.CDNConfig{})
+	// in the order of the catalog, which differs from the order of the paths
+	expected := []paths.AllPathParams{
+		{Module: "example.com/a", Version: "v1.0.0"},
+		{Module: "example.com/a", Version: "v1.0.0-pre"},
+		{Module: "example.com/a-b", Version: "v1.0.0"},
+		{Module: "example.com/a/0x", Version: "v1.0.0"},
+		{Module: "example.com/a/b", Version: "v1.0.0"},
+	}
+	for i := 0; i < 50; i++ {
+		expected = append(expected, paths.AllPathParams{Module: fmt.Sprintf("example.com/z%02d", i), Version: "v1.0.0"})
+	}
+	for _, p := range expected {
+		r.NoError(store.Save(g.context, p.Module, p.Version, mod, bytes.NewReader(zip), info))
+	}
+
+	for _, pageSize := range []int{1, 3, 100} {
+		var all []paths.AllPathParams
+		token := ""
+		for {
+			page, next, err := store.Catalog(g.context, token, pageSize)
+			r.NoError(err)
+			all = append(all, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		r.Equal(expected, all, "page size %d", pageSize)
+	}
+
+	// a page after a token only walks the paths that follow it
+	bucket.walked = 0
+	page, _, err := store.Catalog(g.context, "example.com/z10@v1.0.0", 2)
+	r.NoError(err)
+	r.Equal(expected[16:18], page)
+	r.True(bucket.walked <= 15, "walked %d paths", bucket.walked)
+}
@@ -0,0 +1,78 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "gcp.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(sum)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	err = s.upload(ctx, config.PackageVersionedName(module, version, "sum"), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "gcp.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	path := config.PackageVersionedName(module, version, "sum")
+	exists, err := s.bucket.Exists(ctx, path)
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return sum, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	sumReader, err := s.bucket.Open(ctx, path)
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	b, err := ioutil.ReadAll(sumReader)
+	sumReader.Close()
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := json.Unmarshal(b, &sum); err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return sum, nil
+}
+
+// deleteRecords removes the checksum and the metadata of a version, if any
+func (s *Storage) deleteRecords(ctx context.Context, module, version string) error {
+	const op errors.Op = "gcp.deleteRecords"
+	for _, ext := range []string{"sum", "meta"} {
+		path := config.PackageVersionedName(module, version, ext)
+		exists, err := s.bucket.Exists(ctx, path)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if !exists {
+			continue
+		}
+		if err := s.bucket.Delete(ctx, path); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/cas"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
+
+// casTestSuite runs the compliance tests against
+// the content-addressed layout in the same bucket
+type casTestSuite struct {
+	*TestSuite
+	cas *cas.Storage
+}
+
+func (ts *casTestSuite) Storage() storage.Backend {
+	return ts.cas
+}
+
+func TestContentAddressedCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	gcpTests := ts.(*TestSuite)
+	blobs := &Blobs{bucket: gcpTests.bucket, closeStorage: func() error { return nil }}
+	compliance.RunTests(t, &casTestSuite{gcpTests, cas.New(blobs)})
+}
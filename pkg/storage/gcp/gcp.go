@@ -21,6 +21,9 @@ type Storage struct {
 	projectID    string
 	cdnConf      *config.CDNConfig
 	timeout      time.Duration
+	// sign signs URLs of bucket files, it is nil
+	// if there is no key to sign them with
+	sign func(name string, expiry time.Duration) (string, error)
 }
 
 // New returns a new Storage instance backed by a Google Cloud Storage bucket.
@@ -33,30 +36,42 @@ type Storage struct {
 // See https://cloud.google.com/docs/authentication/getting-started.
 func New(ctx context.Context, gcpConf *config.GCPConfig, cdnConf *config.CDNConfig) (*Storage, error) {
 	const op errors.Op = "gcp.New"
-	storage, err := storage.NewClient(ctx)
-	if err != nil {
-		return nil, errors.E(op, fmt.Errorf("could not create new storage client: %s", err))
-	}
-	bucketname := gcpConf.Bucket
-	u, err := url.Parse(fmt.Sprintf("https://storage.googleapis.com/%s", bucketname))
+	u, err := url.Parse(fmt.Sprintf("https://storage.googleapis.com/%s", gcpConf.Bucket))
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	bkt := gcpBucket{storage.Bucket(bucketname)}
-	err = bkt.Create(ctx, gcpConf.ProjectID, nil)
-	if err != nil && !bucketExistsErr(err) {
+	bkt, closeStorage, err := newBucket(ctx, gcpConf)
+	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
 	return &Storage{
-		bucket:       &bkt,
+		bucket:       bkt,
 		baseURI:      u,
-		closeStorage: storage.Close,
+		closeStorage: closeStorage,
 		cdnConf:      cdnConf,
 		timeout:      gcpConf.TimeoutDuration(),
+		sign:         newSigner(gcpConf.Bucket),
 	}, nil
 }
 
+// newBucket connects to the bucket of gcpConf and creates it if needed.
+// The returned func closes the storage client.
+func newBucket(ctx context.Context, gcpConf *config.GCPConfig) (*gcpBucket, func() error, error) {
+	const op errors.Op = "gcp.newBucket"
+	storage, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, nil, errors.E(op, fmt.Errorf("could not create new storage client: %s", err))
+	}
+	bkt := gcpBucket{storage.Bucket(gcpConf.Bucket)}
+	err = bkt.Create(ctx, gcpConf.ProjectID, nil)
+	if err != nil && !bucketExistsErr(err) {
+		storage.Close()
+		return nil, nil, errors.E(op, err)
+	}
+	return &bkt, storage.Close, nil
+}
+
 func bucketExistsErr(err error) bool {
 	apiErr, ok := err.(*googleapi.Error)
 	if !ok {
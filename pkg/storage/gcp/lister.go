@@ -6,6 +6,7 @@ import (
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
 )
 
 // List implements the (./pkg/storage).Lister interface
@@ -14,7 +15,7 @@ func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
 	const op errors.Op = "gcp.List"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
-	paths, err := s.bucket.List(ctx, module)
+	paths, err := s.bucket.List(ctx, module+"/@v/")
 	if err != nil {
 		return nil, errors.E(op, err, errors.M(module))
 	}
@@ -32,5 +33,6 @@ func extractVersions(paths []string) []string {
 			versions = append(versions, version)
 		}
 	}
+	semver.Sort(versions)
 	return versions
 }
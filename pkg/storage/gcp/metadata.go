@@ -0,0 +1,50 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "gcp.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	err = s.upload(ctx, config.PackageVersionedName(module, version, "meta"), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Stat implements the (./pkg/storage).Statter interface
+func (s *Storage) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "gcp.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var meta storage.Metadata
+	metaReader, err := s.bucket.Open(ctx, config.PackageVersionedName(module, version, "meta"))
+	if err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	b, err := ioutil.ReadAll(metaReader)
+	metaReader.Close()
+	if err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return meta, nil
+}
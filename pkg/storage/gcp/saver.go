@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"io"
-	"log"
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
@@ -47,16 +46,17 @@ func (s *Storage) upload(ctx context.Context, path, contentType string, stream i
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
 	wc := s.bucket.Write(ctx, path)
-	defer func(wc io.WriteCloser) {
-		if err := wc.Close(); err != nil {
-			log.Printf("WARNING: failed to close storage object writer: %s", err)
-		}
-	}(wc)
 	// NOTE: content type is auto detected on GCP side and ACL defaults to public
 	// Once we support private storage buckets this may need refactoring
 	// unless there is a way to set the default perms in the project.
-	if _, err := io.Copy(wc, stream); err != nil {
-		return err
+	_, err := io.Copy(wc, stream)
+	// the object is only written when the writer is closed,
+	// so a failed Close means that it was not uploaded
+	if cerr := wc.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.E(op, err)
 	}
 	return nil
 }
@@ -1,3 +1,20 @@
 package gcp
 
+import "bytes"
+
 // see gcp_test.go for a round-trip test that subsumes tests for the saver
+
+func (g *GcpTests) TestSaveFailedUpload() {
+	r := g.Require()
+	g.bucket.closeErr = ".info"
+	defer func() { g.bucket.closeErr = "" }()
+
+	// the .info file marks the version as saved, so
+	// failing to write it must fail the whole Save
+	err := g.store.Save(g.context, "gcp-test-failed", g.version, mod, bytes.NewReader(zip), info)
+	r.Error(err)
+	exists, err := g.store.Exists(g.context, "gcp-test-failed", g.version)
+	r.NoError(err)
+	r.False(exists)
+	r.True(g.bucket.WriteClosed())
+}
@@ -0,0 +1,80 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// SignedURL implements the (github.com/gomods/athens/pkg/storage).Signer interface.
+// With a CDN endpoint configured it returns the URL of the file on the CDN,
+// otherwise a signed URL of the bucket, which requires the credentials
+// to be a service account key.
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "gcp.SignedURL"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return "", errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return "", errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	name := config.PackageVersionedName(module, version, ext)
+	if u, ok := s.cdnConf.CDNURL(name); ok {
+		return u, nil
+	}
+	if s.sign == nil {
+		return "", errors.E(op, "no service account key to sign URLs with", errors.KindNotImplemented)
+	}
+	u, err := s.sign(name, expiry)
+	if err != nil {
+		return "", errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return u, nil
+}
+
+// newSigner returns a func that signs URLs of the files in bucket with
+// the service account key in GOOGLE_APPLICATION_CREDENTIALS, or nil if
+// the credentials are not a service account key.
+func newSigner(bucket string) func(name string, expiry time.Duration) (string, error) {
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(b, &key); err != nil || key.Type != "service_account" {
+		return nil
+	}
+	return keySigner(bucket, key.ClientEmail, []byte(key.PrivateKey))
+}
+
+// keySigner signs URLs of the files in bucket with
+// the PEM encoded private key of accessID
+func keySigner(bucket, accessID string, key []byte) func(name string, expiry time.Duration) (string, error) {
+	return func(name string, expiry time.Duration) (string, error) {
+		return storage.SignedURL(bucket, name, &storage.SignedURLOptions{
+			GoogleAccessID: accessID,
+			PrivateKey:     key,
+			Method:         "GET",
+			Expires:        time.Now().Add(expiry),
+		})
+	}
+}
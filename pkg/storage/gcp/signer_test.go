@@ -0,0 +1,43 @@
+package gcp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+)
+
+func (g *GcpTests) TestSignedURL() {
+	r := g.Require()
+	module, version := "gcp-signed", "v1.0.0"
+	s := newWithBucket(g.bucket, g.url, time.Second, &config.CDNConfig{})
+
+	_, err := s.SignedURL(g.context, module, version, "zip", time.Minute)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+	r.NoError(s.Save(g.context, module, version, mod, bytes.NewReader(zip), info))
+	_, err = s.SignedURL(g.context, module, version, "zip", time.Minute)
+	r.Equal(errors.KindNotImplemented, errors.Kind(err))
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	r.NoError(err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	s.sign = keySigner("testbucket", "athens@example.iam.gserviceaccount.com", pemKey)
+	u, err := s.SignedURL(g.context, module, version, "zip", time.Minute)
+	r.NoError(err)
+	parsed, err := url.Parse(u)
+	r.NoError(err)
+	r.Equal("/testbucket/gcp-signed/@v/v1.0.0.zip", parsed.Path)
+	r.Equal("athens@example.iam.gserviceaccount.com", parsed.Query().Get("GoogleAccessId"))
+	r.NotEmpty(parsed.Query().Get("Signature"))
+
+	s.cdnConf = &config.CDNConfig{Endpoint: "https://cdn.example.com/athens"}
+	u, err = s.SignedURL(g.context, module, version, "info", time.Minute)
+	r.NoError(err)
+	r.Equal("https://cdn.example.com/athens/gcp-signed/@v/v1.0.0.info", u)
+}
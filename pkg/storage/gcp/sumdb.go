@@ -0,0 +1,48 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "gcp.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	err := s.upload(ctx, storage.SumDBPrefix+path, "application/octet-stream", bytes.NewReader(content))
+	if err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "gcp.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	name := storage.SumDBPrefix + path
+	exists, err := s.bucket.Exists(ctx, name)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(path), errors.KindNotFound)
+	}
+	rc, err := s.bucket.Open(ctx, name)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	return content, nil
+}
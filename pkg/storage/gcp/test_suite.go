@@ -0,0 +1,48 @@
+package gcp
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// TestSuite implements storage.TestSuite interface
+type TestSuite struct {
+	storage *Storage
+	bucket  *bucketMock
+}
+
+// NewTestSuite creates a common test suite
+// backed by an in-memory bucket
+func NewTestSuite() (storage.TestSuite, error) {
+	u, err := url.Parse("https://storage.googleapis.com/testbucket")
+	if err != nil {
+		return nil, err
+	}
+	bucket := newBucketMock()
+
+	return &TestSuite{
+		storage: newWithBucket(bucket, u, 300*time.Second, &config.CDNConfig{}),
+		bucket:  bucket,
+	}, nil
+}
+
+// Storage retrieves initialized storage backend
+func (ts *TestSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+// StorageHumanReadableName retrieves readable identifier of the storage
+func (ts *TestSuite) StorageHumanReadableName() string {
+	return "GCP"
+}
+
+// Cleanup tears down test
+func (ts *TestSuite) Cleanup() error {
+	ts.bucket.lock.Lock()
+	defer ts.bucket.lock.Unlock()
+	ts.bucket.db = make(map[string][]byte)
+	return nil
+}
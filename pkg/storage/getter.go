@@ -11,3 +11,12 @@ type Getter interface {
 	GoMod(ctx context.Context, module, vsn string) ([]byte, error)
 	Zip(ctx context.Context, module, vsn string) (io.ReadCloser, error)
 }
+
+// SizeReadSeekCloser is a zip that knows its size and can be read
+// from any offset. Getters may return one from Zip so that the zip
+// can be served in parts, for example to resume a broken download.
+type SizeReadSeekCloser interface {
+	io.ReadSeeker
+	io.Closer
+	Size() int64
+}
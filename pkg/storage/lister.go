@@ -4,7 +4,10 @@ import "context"
 
 // Lister is the interface that lists versions of a specific baseURL & module
 type Lister interface {
-	// List gets all the versions for the given baseURL & module.
-	// It returns ErrNotFound if the module isn't found
+	// List gets all the versions for the given baseURL & module,
+	// sorted in ascending semver order as by semver.Sort, so that
+	// v1.9.0 comes before v1.10.0. Versions of nested modules
+	// (e.g. module/v2) are not included.
+	// It returns an empty list if the module isn't found
 	List(ctx context.Context, module string) ([]string, error)
 }
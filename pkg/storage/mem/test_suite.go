@@ -2,20 +2,37 @@ package mem
 
 import (
 	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/spf13/afero"
 )
 
 // TestSuite implements storage.TestSuite interface
 type TestSuite struct {
 	storage storage.Backend
+	fs      afero.Fs
+	rootDir string
 }
 
-// NewTestSuite creates a common test suite
+// NewTestSuite creates a common test suite.
+// Unlike NewStorage, every suite gets its own
+// in-memory file system so that suites never share modules.
 func NewTestSuite() (storage.TestSuite, error) {
-	memStore, err := NewStorage()
+	memFs := afero.NewMemMapFs()
+	r, err := afero.TempDir(memFs, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	memStore, err := fs.NewStorage(r, memFs)
+	if err != nil {
+		return nil, err
+	}
 
 	return &TestSuite{
 		storage: memStore,
-	}, err
+		fs:      memFs,
+		rootDir: r,
+	}, nil
 }
 
 // Storage retrieves initialized storage backend
@@ -30,5 +47,5 @@ func (ts *TestSuite) StorageHumanReadableName() string {
 
 // Cleanup tears down test
 func (ts *TestSuite) Cleanup() error {
-	return nil
+	return ts.fs.RemoveAll(ts.rootDir)
 }
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// Metadata records where a module version came from and what it holds
+type Metadata struct {
+	// ZipSize is the size of the zip file in bytes
+	ZipSize int64 `json:"ZipSize"`
+	// FetchedAt is when the version was fetched from upstream
+	FetchedAt time.Time `json:"FetchedAt"`
+	// Origin names the fetcher or upstream the version came from
+	Origin string `json:"Origin"`
+	// Checksum holds the go.sum hashes of the version
+	Checksum
+}
+
+// Statter stores a metadata record alongside each module version
+type Statter interface {
+	// SaveMetadata stores the metadata of a module version,
+	// replacing the one stored before
+	SaveMetadata(ctx context.Context, module, version string, meta Metadata) error
+	// Stat returns the metadata of a module version.
+	// It returns ErrNotFound if no metadata is stored
+	Stat(ctx context.Context, module, version string) (Metadata, error)
+}
@@ -0,0 +1,209 @@
+// Package migrate copies all module versions from one storage backend
+// to another, e.g. to move a cache from Mongo to Minio.
+//
+// Versions are copied in the order of the catalog of the source and
+// every copy is verified against the go.sum checksums of its version.
+// Versions the destination already has are verified and skipped, so a
+// migration that was interrupted can simply be run again. Resuming it
+// from its last checkpoint saves verifying the versions copied before.
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/module"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+const defaultPageSize = 100
+
+// Opts configures a migration
+type Opts struct {
+	// PageSize is the number of versions read from the catalog
+	// of the source at once. Defaults to 100.
+	PageSize int
+	// Token resumes a migration after a checkpoint
+	Token string
+	// DryRun only finds the versions to copy and copies nothing
+	DryRun bool
+	// Checkpoint, if set, is called with a token whenever all versions
+	// of a page are copied. The token resumes the migration there.
+	Checkpoint func(token string) error
+	// Progress, if set, is called for every version of the source
+	// and tells whether it was copied or skipped
+	Progress func(module, version string, copied bool)
+}
+
+// Result tells how many versions a migration copied, or
+// would have copied on a dry run, and how many it skipped
+type Result struct {
+	Copied  int
+	Skipped int
+}
+
+// Migrate copies the versions of from that to does not have yet.
+// It stops at the first version that fails to copy or to verify.
+func Migrate(ctx context.Context, from, to storage.Backend, opts Opts) (Result, error) {
+	const op errors.Op = "migrate.Migrate"
+	var res Result
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	token := opts.Token
+	for {
+		page, next, err := from.Catalog(ctx, token, pageSize)
+		if err != nil {
+			return res, errors.E(op, err)
+		}
+		for _, p := range page {
+			copied, err := migrateVersion(ctx, from, to, p.Module, p.Version, opts.DryRun)
+			if err != nil {
+				return res, errors.E(op, err)
+			}
+			if copied {
+				res.Copied++
+			} else {
+				res.Skipped++
+			}
+			if opts.Progress != nil {
+				opts.Progress(p.Module, p.Version, copied)
+			}
+		}
+		if next == "" {
+			return res, nil
+		}
+		if opts.Checkpoint != nil && !opts.DryRun {
+			if err := opts.Checkpoint(next); err != nil {
+				return res, errors.E(op, err)
+			}
+		}
+		token = next
+	}
+}
+
+// migrateVersion copies a version unless to has it already
+// and returns whether it copied the version
+func migrateVersion(ctx context.Context, from, to storage.Backend, mod, ver string, dryRun bool) (bool, error) {
+	const op errors.Op = "migrate.migrateVersion"
+	exists, err := to.Exists(ctx, mod, ver)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	if dryRun {
+		return !exists, nil
+	}
+
+	info, err := from.Info(ctx, mod, ver)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	goMod, zip, err := read(ctx, from, mod, ver)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	sum, err := sums(goMod, zip)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	// a corrupt source must not turn into a trusted copy
+	stored, err := from.Checksum(ctx, mod, ver)
+	if err == nil && !matches(stored, sum) {
+		return false, errors.E(op, "source does not match its checksum", errors.M(mod), errors.V(ver), errors.KindChecksumMismatch)
+	}
+	if err != nil && errors.Kind(err) != errors.KindNotFound {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+
+	if !exists {
+		err := to.Save(ctx, mod, ver, goMod, bytes.NewReader(zip), info)
+		if err != nil && errors.Kind(err) != errors.KindAlreadyExists {
+			return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+		}
+		// like the stasher, save the checksum after the version,
+		// so that a failed save leaves no checksum behind
+		if err := to.SaveChecksum(ctx, mod, ver, sum); err != nil {
+			return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+		}
+		// versions stashed before metadata was recorded have none
+		meta, err := from.Stat(ctx, mod, ver)
+		if err == nil {
+			err = to.SaveMetadata(ctx, mod, ver, meta)
+		}
+		if err != nil && errors.Kind(err) != errors.KindNotFound {
+			return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+		}
+	}
+
+	if err := verify(ctx, to, mod, ver, sum); err != nil {
+		return false, errors.E(op, err, errors.M(mod), errors.V(ver))
+	}
+	return !exists, nil
+}
+
+// verify reads a version back and compares it to sum
+func verify(ctx context.Context, b storage.Backend, mod, ver string, sum storage.Checksum) error {
+	const op errors.Op = "migrate.verify"
+	goMod, zip, err := read(ctx, b, mod, ver)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	got, err := sums(goMod, zip)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if got != sum {
+		return errors.E(op, "copy does not match the source", errors.KindChecksumMismatch)
+	}
+	return nil
+}
+
+func read(ctx context.Context, b storage.Backend, mod, ver string) ([]byte, []byte, error) {
+	const op errors.Op = "migrate.read"
+	goMod, err := b.GoMod(ctx, mod, ver)
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	zipRC, err := b.Zip(ctx, mod, ver)
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	defer zipRC.Close()
+	zip, err := ioutil.ReadAll(zipRC)
+	if err != nil {
+		return nil, nil, errors.E(op, err)
+	}
+	return goMod, zip, nil
+}
+
+// sums computes the checksum of a version the way the stasher does
+func sums(goMod, zip []byte) (storage.Checksum, error) {
+	const op errors.Op = "migrate.sums"
+	h := sha256.Sum256(zip)
+	sum := storage.Checksum{ZipHash: hex.EncodeToString(h[:])}
+	var err error
+	sum.GoModSum, err = module.HashGoMod(goMod)
+	if err != nil {
+		return sum, errors.E(op, err)
+	}
+	sum.Sum, err = module.HashZip(bytes.NewReader(zip), int64(len(zip)))
+	if err != nil {
+		return sum, errors.E(op, err)
+	}
+	return sum, nil
+}
+
+// matches tells whether the checksum stored for a version matches
+// the one computed from its content. Checksums recorded before
+// ZipHash existed are compared by their go.sum hashes only.
+func matches(stored, sum storage.Checksum) bool {
+	if stored.Sum != sum.Sum || stored.GoModSum != sum.GoModSum {
+		return false
+	}
+	return stored.ZipHash == "" || stored.ZipHash == sum.ZipHash
+}
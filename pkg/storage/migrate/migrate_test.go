@@ -0,0 +1,150 @@
+package migrate_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/stash"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/migrate"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const module = "github.com/athens-artifacts/migrate"
+
+func newBackend(t *testing.T) storage.Backend {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return b
+}
+
+func zipOf(t *testing.T, version string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(module + "@" + version + "/main.go")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("package main"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func save(t *testing.T, b storage.Backend, version string) {
+	t.Helper()
+	err := b.Save(context.Background(), module, version, []byte("module "+module), bytes.NewReader(zipOf(t, version)), []byte("{}"))
+	require.NoError(t, err)
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	from, to := newBackend(t), newBackend(t)
+	for i := 0; i < 5; i++ {
+		save(t, from, fmt.Sprintf("v1.%d.0", i))
+	}
+	save(t, to, "v1.0.0")
+
+	var tokens []string
+	res, err := migrate.Migrate(ctx, from, to, migrate.Opts{
+		PageSize: 2,
+		Checkpoint: func(token string) error {
+			tokens = append(tokens, token)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, migrate.Result{Copied: 4, Skipped: 1}, res)
+	require.Len(t, tokens, 2)
+
+	for i := 0; i < 5; i++ {
+		version := fmt.Sprintf("v1.%d.0", i)
+		exists, err := to.Exists(ctx, module, version)
+		require.NoError(t, err)
+		require.True(t, exists, version)
+		sum, err := to.Checksum(ctx, module, version)
+		if i == 0 {
+			require.Equal(t, errors.KindNotFound, errors.Kind(err), "skipped versions are left alone")
+			continue
+		}
+		require.NoError(t, err)
+		require.NotEmpty(t, sum.Sum)
+		require.NotEmpty(t, sum.GoModSum)
+	}
+
+	// resuming from the last checkpoint only visits the rest
+	res, err = migrate.Migrate(ctx, from, to, migrate.Opts{PageSize: 2, Token: tokens[1]})
+	require.NoError(t, err)
+	require.Equal(t, migrate.Result{Skipped: 1}, res)
+}
+
+// zipFetcher serves the versions that zipOf builds
+type zipFetcher struct {
+	t *testing.T
+}
+
+func (f zipFetcher) Fetch(ctx context.Context, mod, ver string) (*storage.Version, error) {
+	return &storage.Version{
+		Mod:  []byte("module " + module),
+		Info: []byte(`{"Version":"` + ver + `"}`),
+		Zip:  ioutil.NopCloser(bytes.NewReader(zipOf(f.t, ver))),
+	}, nil
+}
+
+func TestMigrateStashedVersion(t *testing.T) {
+	ctx := context.Background()
+	from, to := newBackend(t), newBackend(t)
+	require.NoError(t, stash.New(zipFetcher{t}, from).Stash(ctx, module, "v1.0.0"))
+	sum, err := from.Checksum(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.NotEmpty(t, sum.ZipHash)
+
+	res, err := migrate.Migrate(ctx, from, to, migrate.Opts{})
+	require.NoError(t, err)
+	require.Equal(t, migrate.Result{Copied: 1}, res)
+	copied, err := to.Checksum(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, sum, copied)
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	ctx := context.Background()
+	from, to := newBackend(t), newBackend(t)
+	save(t, from, "v1.0.0")
+	save(t, from, "v1.1.0")
+	save(t, to, "v1.0.0")
+
+	res, err := migrate.Migrate(ctx, from, to, migrate.Opts{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, migrate.Result{Copied: 1, Skipped: 1}, res)
+	exists, err := to.Exists(ctx, module, "v1.1.0")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestMigrateMismatch(t *testing.T) {
+	ctx := context.Background()
+	from, to := newBackend(t), newBackend(t)
+	save(t, from, "v1.0.0")
+	err := to.Save(ctx, module, "v1.0.0", []byte("module "+module), bytes.NewReader(zipOf(t, "v2.0.0")), []byte("{}"))
+	require.NoError(t, err)
+
+	_, err = migrate.Migrate(ctx, from, to, migrate.Opts{})
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err))
+
+	from, to = newBackend(t), newBackend(t)
+	save(t, from, "v1.0.0")
+	require.NoError(t, from.SaveChecksum(ctx, module, "v1.0.0", storage.Checksum{Sum: "h1:corrupt"}))
+	_, err = migrate.Migrate(ctx, from, to, migrate.Opts{})
+	require.Equal(t, errors.KindChecksumMismatch, errors.Kind(err))
+	exists, err := to.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.False(t, exists, "a corrupt source is not copied")
+}
@@ -0,0 +1,91 @@
+package minio
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	minio "github.com/minio/minio-go"
+)
+
+// Blobs implements the (./pkg/storage/cas).Blobs interface
+// and stores every key as an object in a bucket
+type Blobs struct {
+	minioClient *minio.Client
+	bucketName  string
+}
+
+// NewBlobs returns a Blobs that stores everything in the bucket of conf
+func NewBlobs(conf *config.MinioConfig) (*Blobs, error) {
+	const op errors.Op = "minio.NewBlobs"
+	minioClient, err := newClient(conf)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &Blobs{minioClient: minioClient, bucketName: conf.Bucket}, nil
+}
+
+// Open returns a reader for the object of key
+func (b *Blobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op errors.Op = "minio.Blobs.Open"
+	exists, err := b.Exists(ctx, key)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(key))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(key), errors.KindNotFound)
+	}
+	obj, err := b.minioClient.GetObject(b.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(key))
+	}
+	return obj, nil
+}
+
+// Put uploads content as the object of key
+func (b *Blobs) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	const op errors.Op = "minio.Blobs.Put"
+	_, err := b.minioClient.PutObject(b.bucketName, key, content, size, minio.PutObjectOptions{})
+	if err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// Exists returns true if the object of key exists
+func (b *Blobs) Exists(ctx context.Context, key string) (bool, error) {
+	const op errors.Op = "minio.Blobs.Exists"
+	_, err := b.minioClient.StatObject(b.bucketName, key, minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code == minioErrorCodeNoSuchKey {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.E(op, err, errors.M(key))
+	}
+	return true, nil
+}
+
+// Delete removes the object of key, if it exists
+func (b *Blobs) Delete(ctx context.Context, key string) error {
+	const op errors.Op = "minio.Blobs.Delete"
+	if err := b.minioClient.RemoveObject(b.bucketName, key); err != nil {
+		return errors.E(op, err, errors.M(key))
+	}
+	return nil
+}
+
+// List returns the names of all objects that start with prefix
+func (b *Blobs) List(ctx context.Context, prefix string) ([]string, error) {
+	const op errors.Op = "minio.Blobs.List"
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	keys := []string{}
+	for object := range b.minioClient.ListObjectsV2(b.bucketName, prefix, true, doneCh) {
+		if object.Err != nil {
+			return nil, errors.E(op, object.Err, errors.M(prefix))
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,48 @@
+package minio
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It lists the whole bucket for every page.
+func (s *storageImpl) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "minio.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	all := []paths.AllPathParams{}
+	for object := range s.minioClient.ListObjectsV2(s.bucketName, "", true, doneCh) {
+		if object.Err != nil {
+			return nil, "", errors.E(op, object.Err)
+		}
+		// only <module>/<version>/<version>.info marks a version
+		dir, name := splitKey(object.Key)
+		module, version := splitKey(dir)
+		if module == "" || name != version+".info" {
+			continue
+		}
+		all = append(all, paths.AllPathParams{Module: module, Version: version})
+	}
+	page, next, err := storage.CatalogPage(all, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
+
+// splitKey splits a key after its last slash
+func splitKey(key string) (string, string) {
+	i := strings.LastIndex(key, "/")
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
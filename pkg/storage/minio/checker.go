@@ -18,8 +18,9 @@ func (v *storageImpl) Exists(ctx context.Context, module, version string) (bool,
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
 	versionedPath := v.versionLocation(module, version)
-	modPath := fmt.Sprintf("%s/go.mod", versionedPath)
-	_, err := v.minioClient.StatObject(v.bucketName, modPath, minio.StatObjectOptions{})
+	// the .info file is written last by Save and marks a complete version
+	infoPath := fmt.Sprintf("%s/%s.info", versionedPath, version)
+	_, err := v.minioClient.StatObject(v.bucketName, infoPath, minio.StatObjectOptions{})
 
 	if minio.ToErrorResponse(err).Code == minioErrorCodeNoSuchKey {
 		return false, nil
@@ -0,0 +1,52 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	minio "github.com/minio/minio-go"
+)
+
+func (s *storageImpl) checksumLocation(module, version string) string {
+	return s.versionLocation(module, version) + "/checksum.json"
+}
+
+func (s *storageImpl) SaveChecksum(ctx context.Context, module, vsn string, sum storage.Checksum) error {
+	const op errors.Op = "minio.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(sum)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	_, err = s.minioClient.PutObject(s.bucketName, s.checksumLocation(module, vsn), bytes.NewReader(b), int64(len(b)), minio.PutObjectOptions{})
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	return nil
+}
+
+func (s *storageImpl) Checksum(ctx context.Context, module, vsn string) (storage.Checksum, error) {
+	const op errors.Op = "minio.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	sumReader, err := s.minioClient.GetObject(s.bucketName, s.checksumLocation(module, vsn), minio.GetObjectOptions{})
+	if err != nil {
+		return sum, errors.E(op, err)
+	}
+	defer sumReader.Close()
+	b, err := ioutil.ReadAll(sumReader)
+	if err != nil {
+		return sum, transformNotFoundErr(op, module, vsn, err)
+	}
+	if err := json.Unmarshal(b, &sum); err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	return sum, nil
+}
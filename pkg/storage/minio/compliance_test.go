@@ -0,0 +1,43 @@
+package minio
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/cas"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfigFile = filepath.Join("..", "..", "..", "config.dev.toml")
+
+func TestBackendCompliance(t *testing.T) {
+	conf, err := config.GetConf(testConfigFile)
+	require.NoError(t, err)
+	ts, err := NewTestSuite(conf.Storage.Minio)
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
+
+// casTestSuite runs the compliance tests against
+// the content-addressed layout in the same bucket
+type casTestSuite struct {
+	storage.TestSuite
+	cas *cas.Storage
+}
+
+func (ts *casTestSuite) Storage() storage.Backend {
+	return ts.cas
+}
+
+func TestContentAddressedCompliance(t *testing.T) {
+	conf, err := config.GetConf(testConfigFile)
+	require.NoError(t, err)
+	ts, err := NewTestSuite(conf.Storage.Minio)
+	require.NoError(t, err)
+	blobs, err := NewBlobs(conf.Storage.Minio)
+	require.NoError(t, err)
+	compliance.RunTests(t, &casTestSuite{ts, cas.New(blobs)})
+}
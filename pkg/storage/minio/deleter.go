@@ -23,6 +23,13 @@ func (v *storageImpl) Delete(ctx context.Context, module, version string) error
 
 	versionedPath := v.versionLocation(module, version)
 
+	// remove the commit marker first so that
+	// the version disappears as a whole
+	infoPath := fmt.Sprintf("%s/%s.info", versionedPath, version)
+	if err := v.minioClient.RemoveObject(v.bucketName, infoPath); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
 	modPath := fmt.Sprintf("%s/go.mod", versionedPath)
 	if err := v.minioClient.RemoveObject(v.bucketName, modPath); err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
@@ -33,9 +40,11 @@ func (v *storageImpl) Delete(ctx context.Context, module, version string) error
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
 
-	infoPath := fmt.Sprintf("%s/%s.info", versionedPath, version)
-	err = v.minioClient.RemoveObject(v.bucketName, infoPath)
-	if err != nil {
+	if err := v.minioClient.RemoveObject(v.bucketName, v.checksumLocation(module, version)); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	if err := v.minioClient.RemoveObject(v.bucketName, v.metadataLocation(module, version)); err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
 	return nil
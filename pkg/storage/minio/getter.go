@@ -33,6 +33,13 @@ func (v *storageImpl) GoMod(ctx context.Context, module, vsn string) ([]byte, er
 	const op errors.Op = "minio.GoMod"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := v.Exists(ctx, module, vsn)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(vsn), errors.KindNotFound)
+	}
 	modPath := fmt.Sprintf("%s/go.mod", v.versionLocation(module, vsn))
 	modReader, err := v.minioClient.GetObject(v.bucketName, modPath, minio.GetObjectOptions{})
 	if err != nil {
@@ -49,9 +56,16 @@ func (v *storageImpl) Zip(ctx context.Context, module, vsn string) (io.ReadClose
 	const op errors.Op = "minio.Zip"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := v.Exists(ctx, module, vsn)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.M(module), errors.V(vsn), errors.KindNotFound)
+	}
 
 	zipPath := fmt.Sprintf("%s/source.zip", v.versionLocation(module, vsn))
-	_, err := v.minioClient.StatObject(v.bucketName, zipPath, minio.StatObjectOptions{})
+	_, err = v.minioClient.StatObject(v.bucketName, zipPath, minio.StatObjectOptions{})
 	if err != nil {
 		return nil, errors.E(op, err, errors.KindNotFound, errors.M(module), errors.V(vsn))
 	}
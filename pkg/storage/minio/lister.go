@@ -2,11 +2,11 @@ package minio
 
 import (
 	"context"
-	"sort"
 	"strings"
 
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
 )
 
 func (l *storageImpl) List(ctx context.Context, module string) ([]string, error) {
@@ -18,22 +18,24 @@ func (l *storageImpl) List(ctx context.Context, module string) ([]string, error)
 	doneCh := make(chan struct{})
 	defer close(doneCh)
 	searchPrefix := module + "/"
-	objectCh := l.minioClient.ListObjectsV2(l.bucketName, searchPrefix, false, doneCh)
+	objectCh := l.minioClient.ListObjectsV2(l.bucketName, searchPrefix, true, doneCh)
 	for object := range objectCh {
 		if object.Err != nil {
 			return nil, errors.E(op, object.Err, errors.M(module))
 		}
-		parts := strings.Split(object.Key, "/")
-		ver := parts[len(parts)-2]
-		if _, ok := dict[ver]; !ok {
-			dict[ver] = struct{}{}
+		// only <module>/<version>/<version>.info marks a version,
+		// keys of nested modules (e.g. /v2) have more segments
+		parts := strings.Split(strings.TrimPrefix(object.Key, searchPrefix), "/")
+		if len(parts) != 2 || parts[1] != parts[0]+".info" {
+			continue
 		}
+		dict[parts[0]] = struct{}{}
 	}
 
 	ret := []string{}
 	for ver := range dict {
 		ret = append(ret, ver)
 	}
-	sort.Strings(ret)
+	semver.Sort(ret)
 	return ret, nil
 }
@@ -0,0 +1,52 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	minio "github.com/minio/minio-go"
+)
+
+func (s *storageImpl) metadataLocation(module, version string) string {
+	return s.versionLocation(module, version) + "/metadata.json"
+}
+
+func (s *storageImpl) SaveMetadata(ctx context.Context, module, vsn string, meta storage.Metadata) error {
+	const op errors.Op = "minio.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	_, err = s.minioClient.PutObject(s.bucketName, s.metadataLocation(module, vsn), bytes.NewReader(b), int64(len(b)), minio.PutObjectOptions{})
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	return nil
+}
+
+func (s *storageImpl) Stat(ctx context.Context, module, vsn string) (storage.Metadata, error) {
+	const op errors.Op = "minio.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var meta storage.Metadata
+	metaReader, err := s.minioClient.GetObject(s.bucketName, s.metadataLocation(module, vsn), minio.GetObjectOptions{})
+	if err != nil {
+		return meta, errors.E(op, err)
+	}
+	defer metaReader.Close()
+	b, err := ioutil.ReadAll(metaReader)
+	if err != nil {
+		return meta, transformNotFoundErr(op, module, vsn, err)
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	return meta, nil
+}
@@ -22,6 +22,17 @@ func (s *storageImpl) versionLocation(module, version string) string {
 // everything under rootDir
 func NewStorage(conf *config.MinioConfig) (storage.Backend, error) {
 	const op errors.Op = "minio.NewStorage"
+	minioClient, err := newClient(conf)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &storageImpl{minioClient, conf.Bucket}, nil
+}
+
+// newClient connects to the minio server of conf
+// and creates the bucket if needed
+func newClient(conf *config.MinioConfig) (*minio.Client, error) {
+	const op errors.Op = "minio.newClient"
 	endpoint := conf.Endpoint
 	accessKeyID := conf.Key
 	secretAccessKey := conf.Secret
@@ -40,5 +51,5 @@ func NewStorage(conf *config.MinioConfig) (storage.Backend, error) {
 			return nil, errors.E(op, err)
 		}
 	}
-	return &storageImpl{minioClient, bucketName}, nil
+	return minioClient, nil
 }
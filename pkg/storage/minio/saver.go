@@ -10,15 +10,26 @@ import (
 	minio "github.com/minio/minio-go"
 )
 
+// Save uploads the .mod and .zip files of a version before its .info file.
+// The .info file is the commit marker of a version, so a failed Save
+// never leaves a version behind that readers can see.
 func (s *storageImpl) Save(ctx context.Context, module, vsn string, mod []byte, zip io.Reader, info []byte) error {
 	const op errors.Op = "storage.minio.Save"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := s.Exists(ctx, module, vsn)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(vsn), errors.KindAlreadyExists)
+	}
+
 	dir := s.versionLocation(module, vsn)
 	modFileName := dir + "/" + "go.mod"
 	zipFileName := dir + "/" + "source.zip"
 	infoFileName := dir + "/" + vsn + ".info"
-	_, err := s.minioClient.PutObject(s.bucketName, modFileName, bytes.NewReader(mod), int64(len(mod)), minio.PutObjectOptions{})
+	_, err = s.minioClient.PutObject(s.bucketName, modFileName, bytes.NewReader(mod), int64(len(mod)), minio.PutObjectOptions{})
 	if err != nil {
 		return errors.E(op, err)
 	}
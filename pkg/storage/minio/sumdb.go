@@ -0,0 +1,39 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+	minio "github.com/minio/minio-go"
+)
+
+func (s *storageImpl) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "minio.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	_, err := s.minioClient.PutObject(s.bucketName, storage.SumDBPrefix+path, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	if err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+func (s *storageImpl) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "minio.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	rc, err := s.minioClient.GetObject(s.bucketName, storage.SumDBPrefix+path, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(path))
+	}
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, transformNotFoundErr(op, path, "", err)
+	}
+	return content, nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,11 +35,46 @@ func (u *UploadTests) TestUploadTimeout() {
 	rd := bytes.NewReader([]byte("123"))
 	err := Upload(context.Background(), "mx", "1.1.1", rd, rd, rd, uplWithTimeout, time.Second)
 	r.Error(err, "deleter returned at least one error")
-	r.Contains(err.Error(), "uploading mx.1.1.1.info failed: context deadline exceeded")
 	r.Contains(err.Error(), "uploading mx.1.1.1.zip failed: context deadline exceeded")
 	r.Contains(err.Error(), "uploading mx.1.1.1.mod failed: context deadline exceeded")
 }
 
+func (u *UploadTests) TestUploadInfoLast() {
+	r := u.Require()
+	rd := bytes.NewReader([]byte("123"))
+	var lock sync.Mutex
+	uploaded := []string{}
+	upl := func(ctx context.Context, path, contentType string, stream io.Reader) error {
+		lock.Lock()
+		defer lock.Unlock()
+		uploaded = append(uploaded, path)
+		return nil
+	}
+	err := Upload(context.Background(), "mx", "1.1.1", rd, rd, rd, upl, time.Second)
+	r.NoError(err)
+	r.Len(uploaded, 3)
+	r.Equal("mx/@v/1.1.1.info", uploaded[2])
+}
+
+func (u *UploadTests) TestUploadNoInfoOnError() {
+	r := u.Require()
+	rd := bytes.NewReader([]byte("123"))
+	var lock sync.Mutex
+	uploaded := []string{}
+	upl := func(ctx context.Context, path, contentType string, stream io.Reader) error {
+		if strings.HasSuffix(path, ".zip") {
+			return errors.New("some err")
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		uploaded = append(uploaded, path)
+		return nil
+	}
+	err := Upload(context.Background(), "mx", "1.1.1", rd, rd, rd, upl, time.Second)
+	r.Error(err)
+	r.Equal([]string{"mx/@v/1.1.1.mod"}, uploaded)
+}
+
 func (u *UploadTests) TestUploadError() {
 	r := u.Require()
 	rd := bytes.NewReader([]byte("123"))
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface
+func (s *ModuleStore) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "mongo.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	from, err := storage.ParseCatalogToken(token)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	if pageSize <= 0 {
+		return nil, "", errors.E(op, "page size must be positive", errors.KindBadRequest)
+	}
+	query := bson.M{}
+	if token != "" {
+		query = bson.M{"$or": []bson.M{
+			{"module": bson.M{"$gt": from.Module}},
+			{"module": from.Module, "version": bson.M{"$gt": from.Version}},
+		}}
+	}
+	c := s.s.DB(s.d).C(s.c)
+	result := make([]storage.Module, 0)
+	// one more than a page tells if there is a next page
+	err = c.Find(query).
+		Select(bson.M{"module": 1, "version": 1}).
+		Sort("module", "version").
+		Limit(pageSize + 1).
+		All(&result)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+
+	page := make([]paths.AllPathParams, 0, len(result))
+	for _, r := range result {
+		page = append(page, paths.AllPathParams{Module: r.Module, Version: r.Version})
+	}
+	if len(page) <= pageSize {
+		return page, "", nil
+	}
+	page = page[:pageSize]
+	return page, storage.CatalogToken(page[pageSize-1]), nil
+}
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// checksum is the document stored in the checksums collection
+type checksum struct {
+	Module   string `bson:"module"`
+	Version  string `bson:"version"`
+	Sum      string `bson:"sum"`
+	GoModSum string `bson:"go_mod_sum"`
+	ZipHash  string `bson:"zip_hash,omitempty"`
+}
+
+// SaveChecksum implements storage.Checksummer
+func (s *ModuleStore) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "mongo.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.cs)
+	doc := &checksum{Module: module, Version: version, Sum: sum.Sum, GoModSum: sum.GoModSum, ZipHash: sum.ZipHash}
+	_, err := c.Upsert(bson.M{"module": module, "version": version}, doc)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Checksum implements storage.Checksummer
+func (s *ModuleStore) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "mongo.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.cs)
+	result := &checksum{}
+	err := c.Find(bson.M{"module": module, "version": version}).One(result)
+	if err != nil {
+		kind := errors.KindUnexpected
+		if err == mgo.ErrNotFound {
+			kind = errors.KindNotFound
+		}
+		return storage.Checksum{}, errors.E(op, kind, errors.M(module), errors.V(version), err)
+	}
+
+	return storage.Checksum{Sum: result.Sum, GoModSum: result.GoModSum, ZipHash: result.ZipHash}, nil
+}
@@ -0,0 +1,17 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCompliance(t *testing.T) {
+	conf, err := config.GetConf(testConfigFile)
+	require.NoError(t, err)
+	ts, err := NewTestSuite(conf.Storage.Mongo)
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
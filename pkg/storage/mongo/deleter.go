@@ -23,11 +23,21 @@ func (s *ModuleStore) Delete(ctx context.Context, module, version string) error
 
 	db := s.s.DB(s.d)
 	c := db.C(s.c)
+	// remove the module document first so that
+	// the version disappears as a whole
+	err = c.Remove(bson.M{"module": module, "version": version})
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
 	err = db.GridFS("fs").Remove(s.gridFileName(module, version))
 	if err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
-	err = c.Remove(bson.M{"module": module, "version": version})
+	_, err = db.C(s.cs).RemoveAll(bson.M{"module": module, "version": version})
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	_, err = db.C(s.ms).RemoveAll(bson.M{"module": module, "version": version})
 	if err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
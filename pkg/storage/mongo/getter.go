@@ -55,6 +55,15 @@ func (s *ModuleStore) Zip(ctx context.Context, module, vsn string) (io.ReadClose
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
 
+	// a zip without its module document belongs to an unfinished Save
+	exists, err := s.Exists(ctx, module, vsn)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(vsn))
+	}
+	if !exists {
+		return nil, errors.E(op, errors.KindNotFound, errors.M(module), errors.V(vsn))
+	}
+
 	zipName := s.gridFileName(module, vsn)
 	fs := s.s.DB(s.d).GridFS("fs")
 	f, err := fs.Open(zipName)
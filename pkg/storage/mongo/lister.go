@@ -7,6 +7,7 @@ import (
 	"github.com/globalsign/mgo/bson"
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
 	"github.com/gomods/athens/pkg/storage"
 )
 
@@ -30,6 +31,7 @@ func (s *ModuleStore) List(ctx context.Context, module string) ([]string, error)
 	for i, r := range result {
 		versions[i] = r.Version
 	}
+	semver.Sort(versions)
 
 	return versions, nil
 }
@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// metadata is the document stored in the metadata collection
+type metadata struct {
+	Module    string    `bson:"module"`
+	Version   string    `bson:"version"`
+	ZipSize   int64     `bson:"zip_size"`
+	FetchedAt time.Time `bson:"fetched_at"`
+	Origin    string    `bson:"origin"`
+	Sum       string    `bson:"sum"`
+	GoModSum  string    `bson:"go_mod_sum"`
+	ZipHash   string    `bson:"zip_hash,omitempty"`
+}
+
+// SaveMetadata implements storage.Statter
+func (s *ModuleStore) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "mongo.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.ms)
+	doc := &metadata{
+		Module:    module,
+		Version:   version,
+		ZipSize:   meta.ZipSize,
+		FetchedAt: meta.FetchedAt,
+		Origin:    meta.Origin,
+		Sum:       meta.Sum,
+		GoModSum:  meta.GoModSum,
+		ZipHash:   meta.ZipHash,
+	}
+	_, err := c.Upsert(bson.M{"module": module, "version": version}, doc)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// Stat implements storage.Statter
+func (s *ModuleStore) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "mongo.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.ms)
+	result := &metadata{}
+	err := c.Find(bson.M{"module": module, "version": version}).One(result)
+	if err != nil {
+		kind := errors.KindUnexpected
+		if err == mgo.ErrNotFound {
+			kind = errors.KindNotFound
+		}
+		return storage.Metadata{}, errors.E(op, kind, errors.M(module), errors.V(version), err)
+	}
+
+	return storage.Metadata{
+		ZipSize:   result.ZipSize,
+		FetchedAt: result.FetchedAt,
+		Origin:    result.Origin,
+		Checksum:  storage.Checksum{Sum: result.Sum, GoModSum: result.GoModSum, ZipHash: result.ZipHash},
+	}, nil
+}
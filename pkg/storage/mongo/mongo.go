@@ -19,6 +19,9 @@ type ModuleStore struct {
 	s        *mgo.Session
 	d        string // database
 	c        string // collection
+	cs       string // checksums collection
+	ms       string // metadata collection
+	sdb      string // checksum database collection
 	url      string
 	certPath string
 	timeout  time.Duration
@@ -53,6 +56,9 @@ func (m *ModuleStore) connect() error {
 	// TODO: database and collection as env vars, or params to New()? together with user/mongo
 	m.d = "athens"
 	m.c = "modules"
+	m.cs = "checksums"
+	m.ms = "metadata"
+	m.sdb = "sumdb"
 
 	index := mgo.Index{
 		Key:        []string{"base_url", "module", "version"},
@@ -62,7 +68,26 @@ func (m *ModuleStore) connect() error {
 		Sparse:     true,
 	}
 	c := m.s.DB(m.d).C(m.c)
-	return c.EnsureIndex(index)
+	if err := c.EnsureIndex(index); err != nil {
+		return errors.E(op, err)
+	}
+	csIndex := mgo.Index{
+		Key:        []string{"module", "version"},
+		Unique:     true,
+		Background: true,
+	}
+	if err := m.s.DB(m.d).C(m.cs).EnsureIndex(csIndex); err != nil {
+		return errors.E(op, err)
+	}
+	if err := m.s.DB(m.d).C(m.ms).EnsureIndex(csIndex); err != nil {
+		return errors.E(op, err)
+	}
+	sdbIndex := mgo.Index{
+		Key:        []string{"path"},
+		Unique:     true,
+		Background: true,
+	}
+	return m.s.DB(m.d).C(m.sdb).EnsureIndex(sdbIndex)
 }
 
 func (m *ModuleStore) newSession(timeout time.Duration) (*mgo.Session, error) {
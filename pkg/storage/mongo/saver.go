@@ -4,16 +4,27 @@ import (
 	"context"
 	"io"
 
+	"github.com/globalsign/mgo"
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/observ"
 	"github.com/gomods/athens/pkg/storage"
 )
 
 // Save stores a module in mongo storage.
+// The zip is written to GridFS first, the module document is inserted
+// once the zip is complete. The document is the commit marker of a
+// version, so a failed Save leaves no version behind that readers can see.
 func (s *ModuleStore) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
 	const op errors.Op = "mongo.Save"
 	ctx, span := observ.StartSpan(ctx, op.String())
 	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
 
 	zipName := s.gridFileName(module, version)
 	fs := s.s.DB(s.d).GridFS("fs")
@@ -21,10 +32,15 @@ func (s *ModuleStore) Save(ctx context.Context, module, version string, mod []by
 	if err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
-	defer f.Close()
 
 	_, err = io.Copy(f, zip) // check number of bytes written?
 	if err != nil {
+		f.Abort()
+		f.Close()
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	// the file is only visible in GridFS once it is closed
+	if err := f.Close(); err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
 
@@ -37,6 +53,13 @@ func (s *ModuleStore) Save(ctx context.Context, module, version string, mod []by
 
 	c := s.s.DB(s.d).C(s.c)
 	err = c.Insert(m)
+	if err != nil {
+		// best effort, the zip is not reachable without its document
+		fs.RemoveId(f.Id())
+	}
+	if mgo.IsDup(err) {
+		return errors.E(op, err, errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
 	if err != nil {
 		return errors.E(op, err, errors.M(module), errors.V(version))
 	}
@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// sumDBResponse is the document stored in the checksum database collection
+type sumDBResponse struct {
+	Path    string `bson:"path"`
+	Content []byte `bson:"content"`
+}
+
+// SaveSumDB implements storage.SumDBCacher
+func (s *ModuleStore) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "mongo.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.sdb)
+	_, err := c.Upsert(bson.M{"path": path}, &sumDBResponse{Path: path, Content: content})
+	if err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements storage.SumDBCacher
+func (s *ModuleStore) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "mongo.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	c := s.s.DB(s.d).C(s.sdb)
+	result := &sumDBResponse{}
+	err := c.Find(bson.M{"path": path}).One(result)
+	if err != nil {
+		kind := errors.KindUnexpected
+		if err == mgo.ErrNotFound {
+			kind = errors.KindNotFound
+		}
+		return nil, errors.E(op, kind, errors.M(path), err)
+	}
+	return result.Content, nil
+}
@@ -52,5 +52,25 @@ func (ts *TestSuite) Cleanup() error {
 		return nil
 	}
 	defer s.Close()
-	return s.DB(ts.storage.d).C(ts.storage.c).DropCollection()
+	// remove the documents rather than dropping the collection,
+	// which would also drop the unique index created by NewStorage
+	db := s.DB(ts.storage.d)
+	if _, err := db.C(ts.storage.c).RemoveAll(nil); err != nil {
+		return err
+	}
+	if _, err := db.C(ts.storage.cs).RemoveAll(nil); err != nil {
+		return err
+	}
+	if _, err := db.C(ts.storage.ms).RemoveAll(nil); err != nil {
+		return err
+	}
+	if _, err := db.C(ts.storage.sdb).RemoveAll(nil); err != nil {
+		return err
+	}
+	gridFS := db.GridFS("fs")
+	if _, err := gridFS.Files.RemoveAll(nil); err != nil {
+		return err
+	}
+	_, err = gridFS.Chunks.RemoveAll(nil)
+	return err
 }
@@ -0,0 +1,23 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "quota.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	err := s.Backend.Delete(ctx, module, version)
+	if err == nil || errors.IsNotFoundErr(err) {
+		s.forget(module, version)
+	}
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
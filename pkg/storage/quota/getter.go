@@ -0,0 +1,48 @@
+package quota
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "quota.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	info, err := s.Backend.Info(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	s.touch(module, version)
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "quota.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	mod, err := s.Backend.GoMod(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	s.touch(module, version)
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "quota.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	zip, err := s.Backend.Zip(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	s.touch(module, version)
+	return zip, nil
+}
@@ -0,0 +1,242 @@
+// Package quota limits the space a storage backend uses.
+//
+// Storage tracks the size of every version saved and the last time
+// it was read. Once the backend holds more bytes than its quota, the
+// least recently used versions are evicted through the backend's
+// Deleter. Versions of pinned modules are never evicted.
+package quota
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Version is a version held by the backend when a Storage is created
+type Version struct {
+	Module  string
+	Version string
+	// Size is the number of bytes stored for the version
+	Size int64
+	// LastUsed is the last time the version was read or written
+	LastUsed time.Time
+}
+
+// Stats describes the usage of a Storage
+type Stats struct {
+	// MaxBytes is the quota
+	MaxBytes int64
+	// Bytes is the number of bytes stored
+	Bytes int64
+	// Versions is the number of versions stored
+	Versions int
+	// Evictions is the number of versions evicted so far
+	Evictions int64
+	// BytesFreed is the number of bytes freed by evictions so far
+	BytesFreed int64
+}
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	storage.Backend
+	maxBytes int64
+	pinned   []string
+
+	mu         sync.Mutex
+	lru        *list.List // of *entry, most recently used first
+	entries    map[string]*list.Element
+	bytes      int64
+	evictions  int64
+	bytesFreed int64
+}
+
+type entry struct {
+	module  string
+	version string
+	size    int64
+}
+
+func key(module, version string) string {
+	return module + "@" + version
+}
+
+// New returns a Storage that keeps b within maxBytes.
+// A pinned path pins the module with that path and all modules below it.
+// stored lists the versions b already holds, they are
+// evicted first if b is over its quota already.
+func New(b storage.Backend, maxBytes int64, pinned []string, stored []Version) *Storage {
+	s := &Storage{
+		Backend:  b,
+		maxBytes: maxBytes,
+		pinned:   pinned,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	stored = append([]Version(nil), stored...)
+	sort.Slice(stored, func(i, j int) bool { return stored[i].LastUsed.After(stored[j].LastUsed) })
+	s.mu.Lock()
+	for _, v := range stored {
+		e := &entry{module: v.Module, version: v.Version, size: v.Size}
+		s.entries[key(v.Module, v.Version)] = s.lru.PushBack(e)
+		s.bytes += v.Size
+	}
+	victims := s.evict(nil)
+	s.mu.Unlock()
+	s.purge(victims)
+	return s
+}
+
+// Statser is implemented by storages that keep track of their usage,
+// such as Storage, and by storages that wrap one of those
+type Statser interface {
+	// Stats returns the current usage of the storage. It returns an
+	// error of KindNotImplemented if the storage does not track it.
+	Stats() (Stats, error)
+}
+
+// StatsOf returns the usage of b if b is a Statser and returns an error
+// of KindNotImplemented otherwise. Storages that wrap another storage
+// can use it to pass the Statser of the wrapped storage through.
+func StatsOf(b storage.Backend) (Stats, error) {
+	const op errors.Op = "quota.StatsOf"
+	st, ok := b.(Statser)
+	if !ok {
+		return Stats{}, errors.E(op, "storage does not track its usage", errors.KindNotImplemented)
+	}
+	stats, err := st.Stats()
+	if err != nil {
+		return Stats{}, errors.E(op, err)
+	}
+	return stats, nil
+}
+
+// Stats implements the Statser interface
+func (s *Storage) Stats() (Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Stats{
+		MaxBytes:   s.maxBytes,
+		Bytes:      s.bytes,
+		Versions:   s.lru.Len(),
+		Evictions:  s.evictions,
+		BytesFreed: s.bytesFreed,
+	}, nil
+}
+
+func (s *Storage) isPinned(module string) bool {
+	for _, p := range s.pinned {
+		if module == p || strings.HasPrefix(module, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// touch marks a version as the most recently used one
+func (s *Storage) touch(module, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key(module, version)]; ok {
+		s.lru.MoveToFront(el)
+	}
+}
+
+// add records a saved version and makes room for it.
+// It reports false if the version does not fit into the quota,
+// in which case the version is deleted again.
+func (s *Storage) add(module, version string, size int64) bool {
+	s.mu.Lock()
+	k := key(module, version)
+	if _, ok := s.entries[k]; ok {
+		s.mu.Unlock()
+		return true
+	}
+	e := &entry{module: module, version: version, size: size}
+	if !s.isPinned(module) && s.pinnedBytes()+size > s.maxBytes {
+		s.mu.Unlock()
+		s.Backend.Delete(context.Background(), module, version)
+		return false
+	}
+	s.entries[k] = s.lru.PushFront(e)
+	s.bytes += size
+	victims := s.evict(e)
+	s.mu.Unlock()
+	s.purge(victims)
+	return true
+}
+
+// pinnedBytes must be called with s.mu held
+func (s *Storage) pinnedBytes() int64 {
+	var n int64
+	for el := s.lru.Front(); el != nil; el = el.Next() {
+		if e := el.Value.(*entry); s.isPinned(e.module) {
+			n += e.size
+		}
+	}
+	return n
+}
+
+// evict removes the least recently used versions, except pinned
+// ones and keep, from the index until the quota is met or nothing
+// is left to evict, and returns them. They must be deleted from the
+// backend with purge once s.mu is released. It must be called
+// with s.mu held.
+func (s *Storage) evict(keep *entry) []*entry {
+	var victims []*entry
+	for el := s.lru.Back(); el != nil && s.bytes > s.maxBytes; {
+		prev := el.Prev()
+		e := el.Value.(*entry)
+		if e != keep && !s.isPinned(e.module) {
+			s.remove(el)
+			victims = append(victims, e)
+		}
+		el = prev
+	}
+	return victims
+}
+
+// purge deletes the versions evicted from the index from the backend.
+// Versions that fail to delete go back to the index as the least
+// recently used ones, so that they are tried again next time.
+// It must be called without s.mu held.
+func (s *Storage) purge(victims []*entry) {
+	for _, e := range victims {
+		err := s.Backend.Delete(context.Background(), e.module, e.version)
+		s.mu.Lock()
+		switch {
+		case err == nil:
+			s.evictions++
+			s.bytesFreed += e.size
+		case errors.IsNotFoundErr(err):
+		default:
+			k := key(e.module, e.version)
+			if _, ok := s.entries[k]; !ok {
+				s.entries[k] = s.lru.PushBack(e)
+				s.bytes += e.size
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// forget drops a deleted version from the index
+func (s *Storage) forget(module, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key(module, version)]; ok {
+		s.remove(el)
+	}
+}
+
+// remove must be called with s.mu held
+func (s *Storage) remove(el *list.Element) {
+	e := s.lru.Remove(el).(*entry)
+	delete(s.entries, key(e.module, e.version))
+	s.bytes -= e.size
+}
@@ -0,0 +1,198 @@
+package quota_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	module = "github.com/athens-artifacts/quota"
+	// versionSize is the size of the versions saved by save
+	versionSize = 1024
+)
+
+func newBackend(t *testing.T) storage.Backend {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return b
+}
+
+func save(t *testing.T, b storage.Backend, module, version string) error {
+	t.Helper()
+	zip := make([]byte, versionSize-len(version))
+	return b.Save(context.Background(), module, version, nil, bytes.NewReader(zip), []byte(version))
+}
+
+func requireExists(t *testing.T, b storage.Backend, module, version string, expected bool) {
+	t.Helper()
+	exists, err := b.Exists(context.Background(), module, version)
+	require.NoError(t, err)
+	require.Equal(t, expected, exists, "%s@%s", module, version)
+}
+
+func stats(t *testing.T, s *quota.Storage) quota.Stats {
+	t.Helper()
+	st, err := s.Stats()
+	require.NoError(t, err)
+	return st
+}
+
+func TestEvictLeastRecentlyUsed(t *testing.T) {
+	s := quota.New(newBackend(t), 2*versionSize, nil, nil)
+	require.NoError(t, save(t, s, module, "v1.0.0"))
+	require.NoError(t, save(t, s, module, "v1.1.0"))
+	_, err := s.Info(context.Background(), module, "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, save(t, s, module, "v1.2.0"))
+
+	requireExists(t, s, module, "v1.0.0", true)
+	requireExists(t, s, module, "v1.1.0", false)
+	requireExists(t, s, module, "v1.2.0", true)
+	require.Equal(t, quota.Stats{
+		MaxBytes:   2 * versionSize,
+		Bytes:      2 * versionSize,
+		Versions:   2,
+		Evictions:  1,
+		BytesFreed: versionSize,
+	}, stats(t, s))
+}
+
+func TestPinned(t *testing.T) {
+	const pinned = "github.com/athens-artifacts"
+	s := quota.New(newBackend(t), 2*versionSize, []string{pinned}, nil)
+	require.NoError(t, save(t, s, module, "v1.0.0"))
+	require.NoError(t, save(t, s, "github.com/other/mod", "v1.0.0"))
+	require.NoError(t, save(t, s, "github.com/other/mod", "v1.1.0"))
+
+	requireExists(t, s, module, "v1.0.0", true)
+	requireExists(t, s, "github.com/other/mod", "v1.0.0", false)
+	requireExists(t, s, "github.com/other/mod", "v1.1.0", true)
+
+	// pinned versions are kept even over the quota
+	require.NoError(t, save(t, s, module, "v1.1.0"))
+	require.NoError(t, save(t, s, module, "v1.2.0"))
+	requireExists(t, s, "github.com/other/mod", "v1.1.0", false)
+	require.Equal(t, 3, stats(t, s).Versions)
+	require.Equal(t, int64(3*versionSize), stats(t, s).Bytes)
+
+	// and leave no room for other modules
+	err := save(t, s, "github.com/other/mod", "v1.2.0")
+	require.Error(t, err)
+	requireExists(t, s, "github.com/other/mod", "v1.2.0", false)
+}
+
+func TestTooLarge(t *testing.T) {
+	s := quota.New(newBackend(t), versionSize, nil, nil)
+	require.NoError(t, save(t, s, module, "v1.0.0"))
+
+	zip := make([]byte, 2*versionSize)
+	err := s.Save(context.Background(), module, "v1.1.0", nil, bytes.NewReader(zip), nil)
+	require.Error(t, err)
+	requireExists(t, s, module, "v1.1.0", false)
+	// nothing was evicted in vain
+	requireExists(t, s, module, "v1.0.0", true)
+	require.Equal(t, int64(0), stats(t, s).Evictions)
+}
+
+func TestStoredVersions(t *testing.T) {
+	b := newBackend(t)
+	var stored []quota.Version
+	for i := 0; i < 3; i++ {
+		version := fmt.Sprintf("v1.%d.0", i)
+		require.NoError(t, save(t, b, module, version))
+		stored = append(stored, quota.Version{
+			Module:   module,
+			Version:  version,
+			Size:     versionSize,
+			LastUsed: time.Now().Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	s := quota.New(b, 2*versionSize, nil, stored)
+	requireExists(t, s, module, "v1.0.0", false)
+	requireExists(t, s, module, "v1.1.0", true)
+	requireExists(t, s, module, "v1.2.0", true)
+	require.Equal(t, int64(1), stats(t, s).Evictions)
+}
+
+func TestDelete(t *testing.T) {
+	s := quota.New(newBackend(t), 2*versionSize, nil, nil)
+	require.NoError(t, save(t, s, module, "v1.0.0"))
+	require.NoError(t, s.Delete(context.Background(), module, "v1.0.0"))
+	require.Equal(t, int64(0), stats(t, s).Bytes)
+	require.Equal(t, 0, stats(t, s).Versions)
+	require.Equal(t, int64(0), stats(t, s).Evictions)
+}
+
+// statsOnDelete is a backend that reads the stats of
+// its quota whenever a version is deleted from it
+type statsOnDelete struct {
+	storage.Backend
+	s *quota.Storage
+}
+
+func (b *statsOnDelete) Delete(ctx context.Context, module, version string) error {
+	b.s.Stats()
+	return b.Backend.Delete(ctx, module, version)
+}
+
+func TestEvictUnlocked(t *testing.T) {
+	b := &statsOnDelete{Backend: newBackend(t)}
+	s := quota.New(b, versionSize, nil, nil)
+	b.s = s
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, save(t, s, module, "v1.0.0"))
+		require.NoError(t, save(t, s, module, "v1.1.0"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("eviction deleted while holding the lock")
+	}
+	requireExists(t, s, module, "v1.0.0", false)
+	require.Equal(t, int64(1), stats(t, s).Evictions)
+}
+
+// failingDelete is a backend whose deletes fail
+type failingDelete struct {
+	storage.Backend
+}
+
+func (b failingDelete) Delete(ctx context.Context, module, version string) error {
+	return fmt.Errorf("delete failed")
+}
+
+func TestFailedEvictionIsKept(t *testing.T) {
+	s := quota.New(failingDelete{newBackend(t)}, versionSize, nil, nil)
+	require.NoError(t, save(t, s, module, "v1.0.0"))
+	require.NoError(t, save(t, s, module, "v1.1.0"))
+	requireExists(t, s, module, "v1.0.0", true)
+	require.Equal(t, quota.Stats{
+		MaxBytes: versionSize,
+		Bytes:    2 * versionSize,
+		Versions: 2,
+	}, stats(t, s))
+}
+
+func TestStatsOf(t *testing.T) {
+	_, err := quota.StatsOf(newBackend(t))
+	require.Equal(t, errors.KindNotImplemented, errors.Kind(err))
+	st, err := quota.StatsOf(quota.New(newBackend(t), versionSize, nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, int64(versionSize), st.MaxBytes)
+}
@@ -0,0 +1,38 @@
+package quota
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// Saving a version evicts the least recently used versions if
+// the quota is exceeded. A version that does not fit into the
+// quota at all is deleted again and an error is returned.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "quota.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	zr := &countingReader{r: zip}
+	if err := s.Backend.Save(ctx, module, version, mod, zr, info); err != nil {
+		return errors.E(op, err)
+	}
+	if !s.add(module, version, int64(len(mod)+len(info))+zr.n) {
+		return errors.E(op, "version exceeds the storage quota", errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
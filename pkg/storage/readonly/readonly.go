@@ -0,0 +1,76 @@
+// Package readonly provides a storage backend wrapper that serves the
+// module versions of another backend but never changes them, e.g. for
+// proxies that may only serve modules which were approved and cached
+// beforehand.
+package readonly
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/quota"
+)
+
+const errReadOnly = "storage is read-only"
+
+// Storage implements the (./pkg/storage).Backend interface.
+// Reads are served by the wrapped backend, writes fail with KindForbidden.
+type Storage struct {
+	storage.Backend
+}
+
+// New returns a Storage that serves b read-only
+func New(b storage.Backend) *Storage {
+	return &Storage{Backend: b}
+}
+
+// Save implements the (./pkg/storage).Saver interface
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "readonly.Save"
+	return errors.E(op, errReadOnly, errors.M(module), errors.V(version), errors.KindForbidden)
+}
+
+// Delete implements the (./pkg/storage).Deleter interface
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "readonly.Delete"
+	return errors.E(op, errReadOnly, errors.M(module), errors.V(version), errors.KindForbidden)
+}
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "readonly.SaveChecksum"
+	return errors.E(op, errReadOnly, errors.M(module), errors.V(version), errors.KindForbidden)
+}
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "readonly.SaveMetadata"
+	return errors.E(op, errReadOnly, errors.M(module), errors.V(version), errors.KindForbidden)
+}
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "readonly.SaveSumDB"
+	return errors.E(op, errReadOnly, errors.M(path), errors.KindForbidden)
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+// if the wrapped backend implements it
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	return storage.SumDB(ctx, s.Backend, path)
+}
+
+// SignedURL implements the (./pkg/storage).Signer interface
+// if the wrapped backend implements it
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	return storage.SignedURL(ctx, s.Backend, module, version, ext, expiry)
+}
+
+// Stats implements the (./pkg/storage/quota).Statser interface
+// if the wrapped backend implements it
+func (s *Storage) Stats() (quota.Stats, error) {
+	return quota.StatsOf(s.Backend)
+}
@@ -0,0 +1,54 @@
+package readonly
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/mem"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	module  = "github.com/athens-artifacts/readonly"
+	version = "v1.0.0"
+)
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	b, err := mem.NewStorage()
+	require.NoError(t, err)
+	require.NoError(t, b.Save(ctx, module, version, []byte("module "+module), bytes.NewReader([]byte("zip")), []byte("{}")))
+	s := New(b)
+
+	mod, err := s.GoMod(ctx, module, version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("module "+module), mod)
+	zip, err := s.Zip(ctx, module, version)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(zip)
+	zip.Close()
+	require.NoError(t, err)
+	require.Equal(t, []byte("zip"), content)
+
+	err = s.Save(ctx, module, "v1.1.0", []byte("module "+module), bytes.NewReader(nil), []byte("{}"))
+	require.Equal(t, errors.KindForbidden, errors.Kind(err))
+	err = s.SaveChecksum(ctx, module, version, storage.Checksum{Sum: "h1:sum"})
+	require.Equal(t, errors.KindForbidden, errors.Kind(err))
+	err = s.Delete(ctx, module, version)
+	require.Equal(t, errors.KindForbidden, errors.Kind(err))
+	const lookup = "sum.golang.org/lookup/" + module + "@" + version
+	require.NoError(t, storage.SaveSumDB(ctx, b, lookup, []byte("record")))
+	record, err := s.SumDB(ctx, lookup)
+	require.NoError(t, err)
+	require.Equal(t, []byte("record"), record)
+	err = s.SaveSumDB(ctx, lookup, []byte("other record"))
+	require.Equal(t, errors.KindForbidden, errors.Kind(err))
+
+	versions, err := b.List(ctx, module)
+	require.NoError(t, err)
+	require.Equal(t, []string{version}, versions, "the wrapped backend is left untouched")
+}
@@ -0,0 +1,35 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It merges the pages of all replicas that answered, at least
+// one of them has to.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "replicated.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	pages := make([][]paths.AllPathParams, len(s.replicas))
+	nexts := make([]string, len(s.replicas))
+	errs := s.each(func(i int, b storage.Backend) error {
+		var err error
+		pages[i], nexts[i], err = b.Catalog(ctx, token, pageSize)
+		return err
+	})
+	if err := allFailed(errs); err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	more := false
+	for _, next := range nexts {
+		more = more || next != ""
+	}
+	page, next := storage.MergeCatalogPages(pages, more, pageSize)
+	return page, next, nil
+}
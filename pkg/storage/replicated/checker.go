@@ -0,0 +1,31 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Exists implements the (./pkg/storage).Checker interface.
+// A version exists if any replica has it.
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "replicated.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	err := s.read(func(b storage.Backend) error {
+		exists, err := b.Exists(ctx, module, version)
+		if err == nil && !exists {
+			return errors.E(op, errors.KindNotFound)
+		}
+		return err
+	})
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return true, nil
+}
@@ -0,0 +1,41 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "replicated.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var sum storage.Checksum
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		sum, err = b.Checksum(ctx, module, version)
+		return err
+	})
+	if err != nil {
+		return storage.Checksum{}, errors.E(op, err)
+	}
+	return sum, nil
+}
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface.
+// Like versions, checksums are saved to a quorum of the replicas.
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "replicated.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	errs := s.each(func(i int, b storage.Backend) error {
+		return b.SaveChecksum(ctx, module, version, sum)
+	})
+	if err := s.checkQuorum(errs, 0); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
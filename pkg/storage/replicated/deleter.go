@@ -0,0 +1,26 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface.
+// The version is deleted from all replicas and the delete succeeds
+// once a quorum of them does not have it anymore. If none of them
+// had it, the version is not found.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "replicated.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	errs := s.each(func(i int, b storage.Backend) error {
+		return b.Delete(ctx, module, version)
+	})
+	if err := s.checkQuorum(errs, errors.KindNotFound); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
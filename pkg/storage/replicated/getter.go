@@ -0,0 +1,61 @@
+package replicated
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "replicated.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var info []byte
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		info, err = b.Info(ctx, module, version)
+		return err
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "replicated.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var mod []byte
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		mod, err = b.GoMod(ctx, module, version)
+		return err
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "replicated.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var zip io.ReadCloser
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		zip, err = b.Zip(ctx, module, version)
+		return err
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return zip, nil
+}
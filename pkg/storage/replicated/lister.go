@@ -0,0 +1,50 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// List implements the (./pkg/storage).Lister interface.
+// It lists the versions of all replicas that answered,
+// at least one of them has to.
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "replicated.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	lists := make([][]string, len(s.replicas))
+	errs := s.each(func(i int, b storage.Backend) error {
+		var err error
+		lists[i], err = b.List(ctx, module)
+		return err
+	})
+	if err := allFailed(errs); err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	seen := map[string]bool{}
+	versions := []string{}
+	for _, list := range lists {
+		for _, v := range list {
+			if !seen[v] {
+				seen[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
+
+// allFailed returns one of errs if none of them is nil
+func allFailed(errs []error) error {
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errs[0]
+}
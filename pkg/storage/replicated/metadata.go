@@ -0,0 +1,41 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Stat implements the (./pkg/storage).Statter interface
+func (s *Storage) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "replicated.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var meta storage.Metadata
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		meta, err = b.Stat(ctx, module, version)
+		return err
+	})
+	if err != nil {
+		return storage.Metadata{}, errors.E(op, err)
+	}
+	return meta, nil
+}
+
+// SaveMetadata implements the (./pkg/storage).Statter interface.
+// Like versions, metadata is saved to a quorum of the replicas.
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "replicated.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	errs := s.each(func(i int, b storage.Backend) error {
+		return b.SaveMetadata(ctx, module, version, meta)
+	})
+	if err := s.checkQuorum(errs, 0); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package replicated
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/log"
+	"github.com/gomods/athens/pkg/observ"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const repairPageSize = 1000
+
+// Repair copies the module versions in the catalog of all replicas to
+// the replicas that do not have them and returns how many copies it made.
+// A replica that fails to answer is left out of the rest of the repair.
+func (s *Storage) Repair(ctx context.Context) (int, error) {
+	const op errors.Op = "replicated.Repair"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var copies int
+	var errs error
+	skip := make([]bool, len(s.replicas))
+	token := ""
+	for {
+		page, next, err := s.Catalog(ctx, token, repairPageSize)
+		if err != nil {
+			return copies, errors.E(op, err)
+		}
+		for _, p := range page {
+			if err := ctx.Err(); err != nil {
+				return copies, errors.E(op, err)
+			}
+			n, err := s.repairVersion(ctx, p.Module, p.Version, skip)
+			copies += n
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if errs != nil {
+		return copies, errors.E(op, errs)
+	}
+	return copies, nil
+}
+
+// repairVersion copies a version to the replicas that miss it
+func (s *Storage) repairVersion(ctx context.Context, module, version string, skip []bool) (int, error) {
+	const op errors.Op = "replicated.repairVersion"
+	var errs error
+	var missing []int
+	for i, b := range s.replicas {
+		if skip[i] {
+			continue
+		}
+		exists, err := b.Exists(ctx, module, version)
+		s.setHealth(i, err)
+		if err != nil {
+			skip[i] = true
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if !exists {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, wrap(op, errs, module, version)
+	}
+
+	info, err := s.Info(ctx, module, version)
+	if isNotFound(err) {
+		// deleted since the catalog was read
+		return 0, wrap(op, errs, module, version)
+	}
+	if err != nil {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	mod, err := s.GoMod(ctx, module, version)
+	if err != nil {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	zipRC, err := s.Zip(ctx, module, version)
+	if err != nil {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	zip, err := ioutil.ReadAll(zipRC)
+	zipRC.Close()
+	if err != nil {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	sum, err := s.Checksum(ctx, module, version)
+	hasSum := err == nil
+	if err != nil && !isNotFound(err) {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	meta, err := s.Stat(ctx, module, version)
+	hasMeta := err == nil
+	if err != nil && !isNotFound(err) {
+		return 0, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	copies := 0
+	for _, i := range missing {
+		b := s.replicas[i]
+		if hasSum {
+			if err := b.SaveChecksum(ctx, module, version, sum); err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+		}
+		err := b.Save(ctx, module, version, mod, bytes.NewReader(zip), info)
+		if err != nil && errors.Kind(err) != errors.KindAlreadyExists {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if hasMeta {
+			if err := b.SaveMetadata(ctx, module, version, meta); err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+		}
+		copies++
+	}
+	return copies, wrap(op, errs, module, version)
+}
+
+func wrap(op errors.Op, err error, module, version string) error {
+	if err == nil {
+		return nil
+	}
+	return errors.E(op, err, errors.M(module), errors.V(version))
+}
+
+// RepairEvery repairs the replicas every interval until ctx is done
+func (s *Storage) RepairEvery(ctx context.Context, interval time.Duration, lggr log.Entry) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		copies, err := s.Repair(ctx)
+		if copies > 0 {
+			lggr.Infof("copied %d module versions to the replicas that missed them", copies)
+		}
+		if err != nil {
+			lggr.SystemErr(err)
+		}
+	}
+}
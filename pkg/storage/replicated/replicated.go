@@ -0,0 +1,153 @@
+// Package replicated provides a storage backend that keeps a copy of
+// every module version in each of several other backends, its replicas.
+//
+// Saves and deletes go to all replicas at once and succeed once a write
+// quorum of them did, a save that did not is deleted again from the
+// replicas that have it. Reads are served by the first replica that has the
+// version, trying the replicas that answered their last request first.
+// Repair copies the versions that some replicas missed to them, e.g.
+// because they were unreachable or failed while a version was saved.
+//
+// A version that a replica failed to delete is still in the catalog and
+// a repair copies it back to the other replicas. Deletes that did not
+// reach all replicas have to be repeated once all of them are back.
+package replicated
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	replicas []storage.Backend
+	quorum   int
+
+	mu   sync.Mutex
+	down []bool
+}
+
+// New returns a Storage that replicates module versions to replicas.
+// Writes succeed once quorum replicas succeeded, a quorum of 0 requires all of them.
+func New(quorum int, replicas ...storage.Backend) (*Storage, error) {
+	const op errors.Op = "replicated.New"
+	if len(replicas) == 0 {
+		return nil, errors.E(op, "no replicas")
+	}
+	if quorum == 0 {
+		quorum = len(replicas)
+	}
+	if quorum < 0 || quorum > len(replicas) {
+		return nil, errors.E(op, fmt.Sprintf("write quorum %d out of range for %d replicas", quorum, len(replicas)))
+	}
+	return &Storage{
+		replicas: replicas,
+		quorum:   quorum,
+		down:     make([]bool, len(replicas)),
+	}, nil
+}
+
+// setHealth records whether replica i answered. Replicas that do not
+// have a module version answer with KindNotFound, and those that lack
+// an optional interface such as storage.SumDBCacher with KindNotImplemented.
+func (s *Storage) setHealth(i int, err error) {
+	kind := errors.Kind(err)
+	down := err != nil && !isNotFound(err) && kind != errors.KindAlreadyExists && kind != errors.KindNotImplemented
+	s.mu.Lock()
+	s.down[i] = down
+	s.mu.Unlock()
+}
+
+// order returns the indices of the replicas
+// with the ones that answered last time first
+func (s *Storage) order() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	up := make([]int, 0, len(s.replicas))
+	var down []int
+	for i := range s.replicas {
+		if s.down[i] {
+			down = append(down, i)
+		} else {
+			up = append(up, i)
+		}
+	}
+	return append(up, down...)
+}
+
+// read calls f with one replica after the other until one has the
+// module version. It returns KindNotFound only if no replica has the
+// version and all of them answered.
+func (s *Storage) read(f func(b storage.Backend) error) error {
+	var notFound, failed error
+	for _, i := range s.order() {
+		err := f(s.replicas[i])
+		s.setHealth(i, err)
+		switch {
+		case err == nil:
+			return nil
+		case isNotFound(err):
+			notFound = err
+		default:
+			failed = err
+		}
+	}
+	if failed != nil {
+		return failed
+	}
+	return notFound
+}
+
+// each calls f with all replicas and their indices at once
+// and returns their errors in the order of the replicas
+func (s *Storage) each(f func(i int, b storage.Backend) error) []error {
+	errs := make([]error, len(s.replicas))
+	var wg sync.WaitGroup
+	for i, b := range s.replicas {
+		wg.Add(1)
+		go func(i int, b storage.Backend) {
+			defer wg.Done()
+			errs[i] = f(i, b)
+			s.setHealth(i, errs[i])
+		}(i, b)
+	}
+	wg.Wait()
+	return errs
+}
+
+// checkQuorum returns nil if at least a quorum of writes succeeded.
+// Writes that failed with the kind noop, because the replica already
+// was in the state the write leads to, count towards the quorum. If no
+// write actually succeeded, the error of that kind is returned though.
+func (s *Storage) checkQuorum(errs []error, noop int) error {
+	const op errors.Op = "replicated.checkQuorum"
+	var succeeded, noops int
+	var noopErr, failed error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Kind(err) == noop:
+			noops++
+			noopErr = err
+		default:
+			failed = multierror.Append(failed, err)
+		}
+	}
+	if succeeded+noops < s.quorum {
+		msg := fmt.Sprintf("%d of %d replicas succeeded, %d required: %v", succeeded+noops, len(errs), s.quorum, failed)
+		return errors.E(op, msg)
+	}
+	if succeeded == 0 {
+		return noopErr
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Kind(err) == errors.KindNotFound
+}
@@ -0,0 +1,162 @@
+package replicated_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/replicated"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const module = "github.com/athens-artifacts/replicated"
+
+func newBackend(t *testing.T) storage.Backend {
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/athens", 0755))
+	b, err := fs.NewStorage("/athens", memFs)
+	require.NoError(t, err)
+	return b
+}
+
+func save(t *testing.T, b storage.Backend, version string) error {
+	t.Helper()
+	return b.Save(context.Background(), module, version, []byte("module "+module), bytes.NewReader([]byte(version)), []byte("{}"))
+}
+
+// downBackend is a replica that cannot be reached
+type downBackend struct {
+	storage.Backend
+}
+
+func (downBackend) err() error {
+	return errors.E("replicated_test.downBackend", "connection refused")
+}
+
+func (d downBackend) Info(context.Context, string, string) ([]byte, error) {
+	return nil, d.err()
+}
+
+func (d downBackend) Exists(context.Context, string, string) (bool, error) {
+	return false, d.err()
+}
+
+func (d downBackend) Save(context.Context, string, string, []byte, io.Reader, []byte) error {
+	return d.err()
+}
+
+// testSuite runs the compliance tests against three replicas in memory
+type testSuite struct {
+	t       *testing.T
+	storage *replicated.Storage
+}
+
+func (ts *testSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+func (ts *testSuite) StorageHumanReadableName() string {
+	return "Replicated"
+}
+
+func (ts *testSuite) Cleanup() error {
+	ts.storage = newStorage(ts.t, 0, newBackend(ts.t), newBackend(ts.t), newBackend(ts.t))
+	return nil
+}
+
+func newStorage(t *testing.T, quorum int, replicas ...storage.Backend) *replicated.Storage {
+	s, err := replicated.New(quorum, replicas...)
+	require.NoError(t, err)
+	return s
+}
+
+func TestBackendCompliance(t *testing.T) {
+	ts := &testSuite{t: t}
+	ts.Cleanup()
+	compliance.RunTests(t, ts)
+}
+
+func TestNew(t *testing.T) {
+	_, err := replicated.New(0)
+	require.Error(t, err)
+	_, err = replicated.New(3, newBackend(t), newBackend(t))
+	require.Error(t, err)
+}
+
+func TestWriteQuorum(t *testing.T) {
+	ctx := context.Background()
+	up := newBackend(t)
+	down := downBackend{newBackend(t)}
+
+	s := newStorage(t, 1, down, up)
+	require.NoError(t, save(t, s, "v1.0.0"))
+	exists, err := up.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	info, err := s.Info(ctx, module, "v1.0.0")
+	require.NoError(t, err, "reads skip replicas that are down")
+	require.Equal(t, []byte("{}"), info)
+
+	err = save(t, s, "v1.0.0")
+	require.Equal(t, errors.KindAlreadyExists, errors.Kind(err))
+
+	s = newStorage(t, 2, down, up)
+	require.Error(t, save(t, s, "v1.1.0"))
+	exists, err = up.Exists(ctx, module, "v1.1.0")
+	require.NoError(t, err)
+	require.False(t, exists, "a save that misses the quorum is undone")
+	require.NotEqual(t, errors.KindAlreadyExists, errors.Kind(save(t, s, "v1.0.0")))
+	exists, err = up.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.True(t, exists, "versions saved before are kept")
+}
+
+func TestListOrder(t *testing.T) {
+	a, b := newBackend(t), newBackend(t)
+	require.NoError(t, save(t, a, "v1.10.0"))
+	require.NoError(t, save(t, b, "v1.9.0"))
+	require.NoError(t, save(t, b, "v1.10.0"))
+	versions, err := newStorage(t, 1, a, b).List(context.Background(), module)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.9.0", "v1.10.0"}, versions)
+}
+
+func TestRepair(t *testing.T) {
+	ctx := context.Background()
+	a, b := newBackend(t), newBackend(t)
+	s := newStorage(t, 0, a, b)
+	require.NoError(t, save(t, a, "v1.0.0"))
+	require.NoError(t, b.SaveChecksum(ctx, module, "v1.1.0", storage.Checksum{Sum: "h1:b"}))
+	require.NoError(t, save(t, b, "v1.1.0"))
+	require.NoError(t, save(t, s, "v1.2.0"))
+
+	copies, err := s.Repair(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, copies)
+	for _, r := range []storage.Backend{a, b} {
+		versions, err := r.List(ctx, module)
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1.0.0", "v1.1.0", "v1.2.0"}, versions)
+	}
+	sum, err := a.Checksum(ctx, module, "v1.1.0")
+	require.NoError(t, err)
+	require.Equal(t, "h1:b", sum.Sum)
+
+	copies, err = s.Repair(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, copies)
+
+	// a replica that is down is left out
+	s = newStorage(t, 1, a, downBackend{newBackend(t)})
+	require.NoError(t, save(t, a, "v1.3.0"))
+	copies, err = s.Repair(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, copies)
+}
@@ -0,0 +1,58 @@
+package replicated
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// The version is saved to all replicas and the save succeeds once a
+// quorum of them has the version. Replicas that had it already count
+// towards the quorum, but if all of them did, the version already exists.
+// If the quorum is missed, the copies that were saved are deleted again,
+// as no checksum is saved for a version whose save failed.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "replicated.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	// spool the zip to disk, every replica reads it on its own
+	f, err := ioutil.TempFile("", "athens-replicated")
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+	size, err := io.Copy(f, zip)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	errs := s.each(func(i int, b storage.Backend) error {
+		return b.Save(ctx, module, version, mod, io.NewSectionReader(f, 0, size), info)
+	})
+	if err := s.checkQuorum(errs, errors.KindAlreadyExists); err != nil {
+		if errors.Kind(err) != errors.KindAlreadyExists {
+			s.undoSave(ctx, module, version, errs)
+		}
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+// undoSave deletes a version from the replicas that saved it without error.
+// It is best effort, a copy that is left is removed by the next delete.
+func (s *Storage) undoSave(ctx context.Context, module, version string, errs []error) {
+	s.each(func(i int, b storage.Backend) error {
+		if errs[i] != nil {
+			return nil
+		}
+		return b.Delete(ctx, module, version)
+	})
+}
@@ -0,0 +1,42 @@
+package replicated
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface.
+// Responses are saved to a quorum of the replicas, so the replicas
+// that count towards the quorum need to implement it.
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "replicated.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	errs := s.each(func(i int, b storage.Backend) error {
+		return storage.SaveSumDB(ctx, b, path, content)
+	})
+	if err := s.checkQuorum(errs, 0); err != nil {
+		return errors.E(op, err, errors.M(path))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "replicated.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	var content []byte
+	err := s.read(func(b storage.Backend) error {
+		var err error
+		content, err = storage.SumDB(ctx, b, path)
+		return err
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return content, nil
+}
@@ -15,12 +15,12 @@ var (
 
 type S3Tests struct {
 	suite.Suite
-	uploader *s3UploaderMock
-	storage  *Storage
+	client  *s3Mock
+	storage *Storage
 }
 
 func Test_ActionSuite(t *testing.T) {
-	uploaderMock := newUploaderMock()
+	mock := newS3Mock()
 	conf, err := config.GetConf(testConfigFile)
 	if err != nil {
 		t.Fatalf("Unable to parse config file: %s", err.Error())
@@ -28,16 +28,20 @@ func Test_ActionSuite(t *testing.T) {
 	if conf.Storage == nil || conf.Storage.CDN == nil {
 		t.Fatalf("Invalid CDN Config provided")
 	}
-	storage, err := NewWithUploader("test", uploaderMock, conf.Storage.CDN)
+	storage, err := NewWithClient("test", mock, mock, conf.Storage.CDN)
 	if err != nil {
 		t.Error(err)
 	}
 
-	suite.Run(t, &S3Tests{uploader: uploaderMock, storage: storage})
+	suite.Run(t, &S3Tests{client: mock, storage: storage})
+}
+
+func (d *S3Tests) SetupTest() {
+	d.client.clear()
 }
 
 // Verify returns error if S3 state differs from expected one
-func Verify(um *s3UploaderMock, value map[string][]byte) error {
+func Verify(um *s3Mock, value map[string][]byte) error {
 	um.lock.Lock()
 	defer um.lock.Unlock()
 
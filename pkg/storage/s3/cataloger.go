@@ -0,0 +1,45 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It lists the keys of the bucket from the module of token on.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "s3.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	page, next, err := storage.CatalogFromNames(ctx, s.walk, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
+
+// walk implements (./pkg/storage).NameWalker with the
+// StartAfter parameter of the listing of keys
+func (s *Storage) walk(ctx context.Context, prefix, startAfter string, fn func(name string) bool) error {
+	lsParams := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}
+	if startAfter != "" {
+		lsParams.StartAfter = aws.String(startAfter)
+	}
+	return s.client.ListObjectsV2PagesWithContext(ctx, lsParams, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			if !fn(*o.Key) {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,32 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Exists implements the (./pkg/storage).Checker interface
+// returning true if the module at version exists in storage
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "s3.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	hoParams := &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(config.PackageVersionedName(module, version, "info")),
+	}
+	_, err := s.client.HeadObjectWithContext(ctx, hoParams)
+	if isNotFoundErr(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	return true, nil
+}
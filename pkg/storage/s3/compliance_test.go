@@ -0,0 +1,14 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/gomods/athens/pkg/storage/compliance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendCompliance(t *testing.T) {
+	ts, err := NewTestSuite()
+	require.NoError(t, err)
+	compliance.RunTests(t, ts)
+}
@@ -0,0 +1,55 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	modupl "github.com/gomods/athens/pkg/storage/module"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface and
+// removes a version of a module from storage. Returning ErrNotFound
+// if the version does not exist.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "s3.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	err = modupl.Delete(ctx, module, version, s.remove, s.timeout)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	// deleting a missing key is not an error in S3
+	for _, ext := range []string{"sum", "meta"} {
+		err = s.remove(ctx, config.PackageVersionedName(module, version, ext))
+		if err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+	}
+	return nil
+}
+
+func (s *Storage) remove(ctx context.Context, path string) error {
+	const op errors.Op = "s3.remove"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	delParams := &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+	}
+	if _, err := s.client.DeleteObjectWithContext(ctx, delParams); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package s3
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+)
+
+// List implements the (./pkg/storage).Lister interface
+// It returns a list of versions, if any, for a given module
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "s3.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	lsParams := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(module + "/@v/"),
+	}
+
+	versions := []string{}
+	collect := func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			if v, ok := extractVersion(*o.Key); ok {
+				versions = append(versions, v)
+			}
+		}
+		return true
+	}
+	if err := s.client.ListObjectsV2PagesWithContext(ctx, lsParams, collect); err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+
+	semver.Sort(versions)
+	return versions, nil
+}
+
+// extractVersion returns the version encoded in the
+// key of a .info file, the other files are ignored
+func extractVersion(key string) (string, bool) {
+	if !strings.HasSuffix(key, ".info") {
+		return "", false
+	}
+	segments := strings.Split(key, "/")
+	// version should be last segment w/ .info suffix
+	last := segments[len(segments)-1]
+	return strings.TrimSuffix(last, ".info"), true
+}
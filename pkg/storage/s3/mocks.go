@@ -1,42 +1,133 @@
 package s3
 
 import (
+	"bytes"
 	"io/ioutil"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
-type s3UploaderMock struct {
+// s3Mock is an in-process fake of a single S3 bucket. It implements
+// both the uploader and the subset of the S3 API the storage uses.
+type s3Mock struct {
+	s3iface.S3API
 	db   map[string][]byte
 	lock sync.Mutex
+	// pageSize is the number of keys in the pages of listings,
+	// listed counts the keys listed so far
+	pageSize int
+	listed   int
 }
 
-func newUploaderMock() *s3UploaderMock {
-	u := &s3UploaderMock{}
-	u.db = make(map[string][]byte)
-	return u
+func newS3Mock() *s3Mock {
+	m := &s3Mock{}
+	m.db = make(map[string][]byte)
+	return m
 }
 
-func (u *s3UploaderMock) Upload(input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+func (m *s3Mock) Upload(input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	return m.UploadWithContext(aws.BackgroundContext(), input, opts...)
+}
+
+func (m *s3Mock) UploadWithContext(ctx aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
 	content, err := ioutil.ReadAll(input.Body)
 	if err != nil {
 		return nil, err
 	}
-	u.lock.Lock()
-	u.db[*input.Key] = content
-	u.lock.Unlock()
+	m.lock.Lock()
+	m.db[*input.Key] = content
+	m.lock.Unlock()
 	return &s3manager.UploadOutput{}, nil
 }
 
-func (u *s3UploaderMock) UploadWithContext(ctx aws.Context, input *s3manager.UploadInput, opts ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
-	content, err := ioutil.ReadAll(input.Body)
-	if err != nil {
-		return nil, err
+func (m *s3Mock) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	content, ok := m.db[*input.Key]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil)
 	}
-	u.lock.Lock()
-	u.db[*input.Key] = content
-	u.lock.Unlock()
-	return &s3manager.UploadOutput{}, nil
+	return &s3.GetObjectOutput{
+		Body:          ioutil.NopCloser(bytes.NewReader(content)),
+		ContentLength: aws.Int64(int64(len(content))),
+	}, nil
+}
+
+func (m *s3Mock) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	content, ok := m.db[*input.Key]
+	if !ok {
+		return nil, awserr.New("NotFound", "Not Found", nil)
+	}
+	return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(content)))}, nil
+}
+
+func (m *s3Mock) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error {
+	m.lock.Lock()
+	keys := []string{}
+	for k := range m.db {
+		if strings.HasPrefix(k, aws.StringValue(input.Prefix)) && k > aws.StringValue(input.StartAfter) {
+			keys = append(keys, k)
+		}
+	}
+	m.lock.Unlock()
+	// S3 returns keys in ascending UTF-8 binary order
+	sort.Strings(keys)
+
+	pageSize := m.pageSize
+	if pageSize == 0 {
+		pageSize = 1000
+	}
+	for len(keys) > 0 {
+		n := pageSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		page := &s3.ListObjectsV2Output{}
+		for _, k := range keys[:n] {
+			page.Contents = append(page.Contents, &s3.Object{Key: aws.String(k)})
+		}
+		keys = keys[n:]
+		m.lock.Lock()
+		m.listed += n
+		m.lock.Unlock()
+		if !fn(page, len(keys) == 0) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *s3Mock) DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.db, *input.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+// GetObjectRequest builds the request with a real client,
+// which is all it takes to presign it
+func (m *s3Mock) GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+	}))
+	return s3.New(sess).GetObjectRequest(input)
+}
+
+func (m *s3Mock) clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.db = make(map[string][]byte)
 }
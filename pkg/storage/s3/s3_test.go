@@ -0,0 +1,122 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/paths"
+)
+
+func (d *S3Tests) TestSaveGetListExistsRoundTrip() {
+	r := d.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	r.NoError(d.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	modBts, err := d.storage.GoMod(ctx, module, version)
+	r.NoError(err)
+	r.Equal(mod, modBts)
+
+	infoBts, err := d.storage.Info(ctx, module, version)
+	r.NoError(err)
+	r.Equal(info, infoBts)
+
+	ziprc, err := d.storage.Zip(ctx, module, version)
+	r.NoError(err)
+	gotZip, err := ioutil.ReadAll(ziprc)
+	r.NoError(ziprc.Close())
+	r.NoError(err)
+	r.Equal(zip, gotZip)
+
+	versions, err := d.storage.List(ctx, module)
+	r.NoError(err)
+	r.Equal([]string{version}, versions)
+
+	exists, err := d.storage.Exists(ctx, module, version)
+	r.NoError(err)
+	r.True(exists)
+
+	err = d.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info)
+	r.Equal(errors.KindAlreadyExists, errors.Kind(err))
+}
+
+func (d *S3Tests) TestDelete() {
+	r := d.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	r.NoError(d.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+	r.NoError(d.storage.Delete(ctx, module, version))
+
+	exists, err := d.storage.Exists(ctx, module, version)
+	r.NoError(err)
+	r.False(exists)
+	r.NoError(Verify(d.client, map[string][]byte{}))
+
+	err = d.storage.Delete(ctx, module, version)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+}
+
+func (d *S3Tests) TestNotFounds() {
+	r := d.Require()
+	ctx := context.Background()
+
+	_, err := d.storage.Info(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+	_, err = d.storage.GoMod(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+	_, err = d.storage.Zip(ctx, "never", "there")
+	r.True(errors.IsNotFoundErr(err))
+
+	list, err := d.storage.List(ctx, "nothing/to/see/here")
+	r.NoError(err)
+	r.Equal(0, len(list))
+}
+
+func (d *S3Tests) TestCatalogListsFromToken() {
+	r := d.Require()
+	ctx := context.Background()
+	// in the order of the catalog, which differs from the order of the keys
+	expected := []paths.AllPathParams{
+		{Module: "example.com/a", Version: "v1.0.0"},
+		{Module: "example.com/a", Version: "v1.0.0-pre"},
+		{Module: "example.com/a-b", Version: "v1.0.0"},
+		{Module: "example.com/a.c", Version: "v1.0.0"},
+		{Module: "example.com/a/0x", Version: "v1.0.0"},
+		{Module: "example.com/a/b", Version: "v1.0.0"},
+	}
+	for i := 0; i < 50; i++ {
+		expected = append(expected, paths.AllPathParams{Module: fmt.Sprintf("example.com/z%02d", i), Version: "v1.0.0"})
+	}
+	for _, p := range expected {
+		r.NoError(d.storage.Save(ctx, p.Module, p.Version, mod, bytes.NewReader(zip), info))
+	}
+
+	d.client.pageSize = 10
+	defer func() { d.client.pageSize = 0 }()
+	for _, pageSize := range []int{1, 2, 3, 100} {
+		var all []paths.AllPathParams
+		token := ""
+		for {
+			page, next, err := d.storage.Catalog(ctx, token, pageSize)
+			r.NoError(err)
+			all = append(all, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		r.Equal(expected, all, "page size %d", pageSize)
+	}
+
+	// a page after a token does not list the whole bucket
+	d.client.listed = 0
+	page, _, err := d.storage.Catalog(ctx, "example.com/z10@v1.0.0", 2)
+	r.NoError(err)
+	r.Equal(expected[17:19], page)
+	r.True(d.client.listed <= 20, "listed %d keys", d.client.listed)
+}
@@ -0,0 +1,49 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	moduploader "github.com/gomods/athens/pkg/storage/module"
+)
+
+// Save implements the (github.com/gomods/athens/pkg/storage).Saver interface.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "s3.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
+
+	err = moduploader.Upload(ctx, module, version, bytes.NewReader(info), bytes.NewReader(mod), zip, s.upload, s.timeout)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
+
+func (s *Storage) upload(ctx context.Context, path, contentType string, stream io.Reader) error {
+	const op errors.Op = "s3.upload"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	upParams := &s3manager.UploadInput{
+		Bucket:      &s.bucket,
+		Key:         &path,
+		Body:        stream,
+		ContentType: &contentType,
+	}
+	_, err := s.uploader.UploadWithContext(ctx, upParams)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
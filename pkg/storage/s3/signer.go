@@ -0,0 +1,42 @@
+package s3
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// SignedURL implements the (github.com/gomods/athens/pkg/storage).Signer interface.
+// With a CDN endpoint configured it returns the URL of the file on the CDN,
+// otherwise a presigned URL of the bucket.
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "s3.SignedURL"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return "", errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return "", errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+
+	key := config.PackageVersionedName(module, version, ext)
+	if u, ok := s.cdnConf.CDNURL(key); ok {
+		return u, nil
+	}
+	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	u, err := req.Presign(expiry)
+	if err != nil {
+		return "", errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return u, nil
+}
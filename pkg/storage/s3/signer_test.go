@@ -0,0 +1,36 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+)
+
+func (d *S3Tests) TestSignedURL() {
+	r := d.Require()
+	ctx := context.Background()
+	module, version := "github.com/gomods/athens", "v1.2.3"
+
+	_, err := d.storage.SignedURL(ctx, module, version, "zip", time.Minute)
+	r.Equal(errors.KindNotFound, errors.Kind(err))
+	r.NoError(d.storage.Save(ctx, module, version, mod, bytes.NewReader(zip), info))
+
+	// the suite config sets a CDN endpoint
+	u, err := d.storage.SignedURL(ctx, module, version, "zip", time.Minute)
+	r.NoError(err)
+	r.Equal("https://cdn.example.com/github.com/gomods/athens/@v/v1.2.3.zip", u)
+
+	s, err := NewWithClient("test", d.client, d.client, &config.CDNConfig{})
+	r.NoError(err)
+	u, err = s.SignedURL(ctx, module, version, "mod", time.Minute)
+	r.NoError(err)
+	parsed, err := url.Parse(u)
+	r.NoError(err)
+	r.Equal("/github.com/gomods/athens/@v/v1.2.3.mod", parsed.Path)
+	r.Equal("60", parsed.Query().Get("X-Amz-Expires"))
+	r.NotEmpty(parsed.Query().Get("X-Amz-Signature"))
+}
@@ -1,22 +1,23 @@
 package s3
 
 import (
-	"bytes"
-	"context"
 	"fmt"
-	"io"
 	"net/url"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
 	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/errors"
-	"github.com/gomods/athens/pkg/observ"
-	moduploader "github.com/gomods/athens/pkg/storage/module"
 )
 
-// Storage implements (github.com/gomods/athens/pkg/storage).Saver and
+// Storage implements (github.com/gomods/athens/pkg/storage).Backend and
 // also provides a function to fetch the location of a module
 // Storage uses amazon aws go SDK which expects these env variables
 // - AWS_REGION 			- region for this storage, e.g 'us-west-2'
@@ -27,36 +28,46 @@ import (
 type Storage struct {
 	bucket   string
 	baseURI  *url.URL
+	client   s3iface.S3API
 	uploader s3manageriface.UploaderAPI
 	cdnConf  *config.CDNConfig
+	timeout  time.Duration
 }
 
 // New creates a new AWS S3 CDN saver
-func New(bucketName string, cdnConf *config.CDNConfig) (*Storage, error) {
+func New(s3Conf *config.S3Config, cdnConf *config.CDNConfig) (*Storage, error) {
 	const op errors.Op = "s3.New"
-	u, err := url.Parse(fmt.Sprintf("http://%s.s3.amazonaws.com", bucketName))
+	u, err := url.Parse(fmt.Sprintf("http://%s.s3.amazonaws.com", s3Conf.Bucket))
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
+	awsConfig := &aws.Config{Region: aws.String(s3Conf.Region)}
+	// static credentials take precedence, otherwise
+	// fall back to the default aws credential chain
+	if s3Conf.Key != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(s3Conf.Key, s3Conf.Secret, s3Conf.Token)
+	}
+
 	// create a session
-	sess, err := session.NewSession()
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	uploader := s3manager.NewUploader(sess)
 
 	return &Storage{
-		bucket:   bucketName,
-		uploader: uploader,
+		bucket:   s3Conf.Bucket,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
 		baseURI:  u,
 		cdnConf:  cdnConf,
+		timeout:  s3Conf.TimeoutDuration(),
 	}, nil
 }
 
-// NewWithUploader creates a new AWS S3 CDN saver with provided uploader
-func NewWithUploader(bucketName string, uploader s3manageriface.UploaderAPI, cdnConf *config.CDNConfig) (*Storage, error) {
-	const op errors.Op = "s3.NewWithUploader"
+// NewWithClient creates a new AWS S3 CDN saver with provided client and uploader
+func NewWithClient(bucketName string, client s3iface.S3API, uploader s3manageriface.UploaderAPI, cdnConf *config.CDNConfig) (*Storage, error) {
+	const op errors.Op = "s3.NewWithClient"
 	u, err := url.Parse(fmt.Sprintf("http://%s.s3.amazonaws.com", bucketName))
 	if err != nil {
 		return nil, errors.E(op, err)
@@ -64,9 +75,11 @@ func NewWithUploader(bucketName string, uploader s3manageriface.UploaderAPI, cdn
 
 	return &Storage{
 		bucket:   bucketName,
+		client:   client,
 		uploader: uploader,
 		baseURI:  u,
 		cdnConf:  cdnConf,
+		timeout:  cdnConf.TimeoutDuration(),
 	}, nil
 }
 
@@ -80,34 +93,13 @@ func (s Storage) BaseURL() *url.URL {
 	return s.cdnConf.CDNEndpointWithDefault(s.baseURI)
 }
 
-// Save implements the (github.com/gomods/athens/pkg/storage).Saver interface.
-func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
-	const op errors.Op = "s3.Save"
-	ctx, span := observ.StartSpan(ctx, op.String())
-	defer span.End()
-	err := moduploader.Upload(ctx, module, version, bytes.NewReader(info), bytes.NewReader(mod), zip, s.upload, s.cdnConf.TimeoutDuration())
-	// TODO: take out lease on the /list file and add the version to it
-	//
-	// Do that only after module source+metadata is uploaded
-	if err != nil {
-		return errors.E(op, err, errors.M(module), errors.V(version))
-	}
-	return nil
-}
-
-func (s *Storage) upload(ctx context.Context, path, contentType string, stream io.Reader) error {
-	const op errors.Op = "s3.upload"
-	ctx, span := observ.StartSpan(ctx, op.String())
-	defer span.End()
-	upParams := &s3manager.UploadInput{
-		Bucket:      &s.bucket,
-		Key:         &path,
-		Body:        stream,
-		ContentType: &contentType,
-	}
-	_, err := s.uploader.UploadWithContext(ctx, upParams)
-	if err != nil {
-		return errors.E(op, err)
+// isNotFoundErr reports whether err is the error the S3 API returns for
+// missing keys. GetObject reports NoSuchKey while HeadObject, which has no
+// response body, only reports a generic NotFound code.
+func isNotFoundErr(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return false
 	}
-	return nil
+	return aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound"
 }
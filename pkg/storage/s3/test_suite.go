@@ -0,0 +1,44 @@
+package s3
+
+import (
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// TestSuite implements storage.TestSuite interface
+type TestSuite struct {
+	storage *Storage
+	mock    *s3Mock
+}
+
+// NewTestSuite creates a common test suite
+// backed by an in-process fake of the S3 API
+func NewTestSuite() (storage.TestSuite, error) {
+	mock := newS3Mock()
+	cdnConf := &config.CDNConfig{TimeoutConf: config.TimeoutConf{Timeout: 300}}
+	s3Store, err := NewWithClient("test", mock, mock, cdnConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestSuite{
+		storage: s3Store,
+		mock:    mock,
+	}, nil
+}
+
+// Storage retrieves initialized storage backend
+func (ts *TestSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+// StorageHumanReadableName retrieves readable identifier of the storage
+func (ts *TestSuite) StorageHumanReadableName() string {
+	return "S3"
+}
+
+// Cleanup tears down test
+func (ts *TestSuite) Cleanup() error {
+	ts.mock.clear()
+	return nil
+}
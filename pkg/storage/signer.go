@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// Signer is implemented by storages that can hand out URLs
+// which download a stored file straight from the storage,
+// without passing through the proxy.
+type Signer interface {
+	// SignedURL returns a URL that downloads the .info, .mod or .zip
+	// file, as given by ext, of module@version for at least expiry.
+	// It returns an error of KindNotFound if the version is not stored.
+	SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error)
+}
+
+// SignedURL signs a URL with b if b is a Signer and returns an error of
+// KindNotImplemented otherwise. Storages that wrap another storage can
+// use it to pass the Signer of the wrapped storage through.
+func SignedURL(ctx context.Context, b Backend, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "storage.SignedURL"
+	s, ok := b.(Signer)
+	if !ok {
+		return "", errors.E(op, "storage can not sign URLs", errors.KindNotImplemented)
+	}
+	u, err := s.SignedURL(ctx, module, version, ext, expiry)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return u, nil
+}
@@ -20,10 +20,14 @@ import (
 	"github.com/gomods/athens/pkg/config"
 	"github.com/gomods/athens/pkg/errors"
 	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/azurecdn"
 	"github.com/gomods/athens/pkg/storage/fs"
 	"github.com/gomods/athens/pkg/storage/mem"
 	"github.com/gomods/athens/pkg/storage/minio"
 	"github.com/gomods/athens/pkg/storage/mongo"
+	"github.com/gomods/athens/pkg/storage/s3"
+	"github.com/gomods/athens/pkg/storage/tiered"
+	"github.com/gomods/athens/pkg/storage/webdav"
 )
 
 var (
@@ -66,6 +70,26 @@ func (d *TestSuites) SetupTest() {
 	ra.NoError(err)
 	d.storages = append(d.storages, mongoStore)
 
+	// s3
+	s3Store, err := s3.NewTestSuite()
+	ra.NoError(err)
+	d.storages = append(d.storages, s3Store)
+
+	// azure blob
+	azureStore, err := azurecdn.NewTestSuite()
+	ra.NoError(err)
+	d.storages = append(d.storages, azureStore)
+
+	// webdav
+	davStore, err := webdav.NewTestSuite()
+	ra.NoError(err)
+	d.storages = append(d.storages, davStore)
+
+	// disk cache in front of a remote backend
+	tieredStore, err := tiered.NewTestSuite()
+	ra.NoError(err)
+	d.storages = append(d.storages, tieredStore)
+
 	d.module = "testmodule"
 	d.version = "v1.0.0"
 	d.mod = []byte("123")
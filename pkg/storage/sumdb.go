@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// SumDBCacher is implemented by storages that can keep the responses of
+// checksum databases, so that the proxy asks a checksum database only
+// once for each of its records and tiles, which never change.
+type SumDBCacher interface {
+	// SaveSumDB stores the response to path below the URL of a checksum
+	// database, such as "sum.golang.org/tile/8/0/001", replacing the one
+	// stored before
+	SaveSumDB(ctx context.Context, path string, content []byte) error
+	// SumDB returns the response to path stored by SaveSumDB.
+	// It returns an error of KindNotFound if nothing is stored
+	SumDB(ctx context.Context, path string) ([]byte, error)
+}
+
+// SumDBPrefix is the prefix of the names that object storages keep the
+// responses of checksum databases under. No module path starts with "~",
+// so the responses sort after all module versions and stay out of the way
+// of listings in the order of the catalog.
+const SumDBPrefix = "~sumdb/"
+
+// SaveSumDB stores content in b if b is a SumDBCacher and returns an error
+// of KindNotImplemented otherwise. Storages that wrap another storage can
+// use it to pass the SumDBCacher of the wrapped storage through.
+func SaveSumDB(ctx context.Context, b Backend, path string, content []byte) error {
+	const op errors.Op = "storage.SaveSumDB"
+	c, ok := b.(SumDBCacher)
+	if !ok {
+		return errors.E(op, "storage can not cache checksum databases", errors.KindNotImplemented)
+	}
+	if err := c.SaveSumDB(ctx, path, content); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// SumDB returns the content stored for path in b if b is a SumDBCacher
+// and returns an error of KindNotImplemented otherwise.
+func SumDB(ctx context.Context, b Backend, path string) ([]byte, error) {
+	const op errors.Op = "storage.SumDB"
+	c, ok := b.(SumDBCacher)
+	if !ok {
+		return nil, errors.E(op, "storage can not cache checksum databases", errors.KindNotImplemented)
+	}
+	content, err := c.SumDB(ctx, path)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return content, nil
+}
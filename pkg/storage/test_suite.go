@@ -1,6 +1,7 @@
 package storage
 
-// TestSuite is common interface which each storage needs to implement
+// TestSuite is common interface which each storage needs to implement.
+// See the compliance package for the tests run against it.
 type TestSuite interface {
 	Storage() Backend
 	StorageHumanReadableName() string
@@ -0,0 +1,84 @@
+package tiered
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// isCached reports whether a version is in the cache
+func (s *Storage) isCached(ctx context.Context, module, version string) bool {
+	exists, err := s.local.Exists(ctx, module, version)
+	return err == nil && exists
+}
+
+// drop removes a version from the cache, if it is cached
+func (s *Storage) drop(ctx context.Context, module, version string) {
+	// a version that can not be deleted stays unknown to the cache,
+	// it is overwritten if it is ever cached again
+	s.local.Delete(ctx, module, version)
+}
+
+// cached reports whether a version is in the cache, and copies
+// it there from the remote backend first if it is not.
+// Versions that can not be cached are served from the remote backend.
+func (s *Storage) cached(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "tiered.cached"
+	if s.isCached(ctx, module, version) {
+		return true, nil
+	}
+	exists, err := s.remote.Exists(ctx, module, version)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return false, errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	// the cache is best effort, errors only mean a cache miss
+	return s.fill(ctx, module, version) == nil, nil
+}
+
+// fill copies a version from the remote backend to the cache
+func (s *Storage) fill(ctx context.Context, module, version string) error {
+	const op errors.Op = "tiered.fill"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	info, err := s.remote.Info(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	mod, err := s.remote.GoMod(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	zip, err := s.remote.Zip(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer zip.Close()
+	return s.store(ctx, module, version, mod, zip, info)
+}
+
+// store writes a version stored in the remote backend to the cache
+func (s *Storage) store(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "tiered.store"
+	sum, err := s.remote.Checksum(ctx, module, version)
+	if err == nil {
+		err = s.local.SaveChecksum(ctx, module, version, sum)
+	}
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return errors.E(op, err)
+	}
+
+	err = s.local.Save(ctx, module, version, mod, zip, info)
+	if errors.Kind(err) == errors.KindAlreadyExists {
+		// cached by a concurrent read meanwhile
+		return nil
+	}
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// The cache only holds some of the versions of the remote
+// backend, so the catalog is always that of the remote backend.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "tiered.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	page, next, err := s.remote.Catalog(ctx, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
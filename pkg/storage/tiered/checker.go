@@ -0,0 +1,25 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Exists implements the (./pkg/storage).Checker interface.
+// Cached versions exist without asking the remote backend,
+// they are only cached once the remote backend has them.
+func (s *Storage) Exists(ctx context.Context, module, version string) (bool, error) {
+	const op errors.Op = "tiered.Exists"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if s.isCached(ctx, module, version) {
+		return true, nil
+	}
+	exists, err := s.remote.Exists(ctx, module, version)
+	if err != nil {
+		return false, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return exists, nil
+}
@@ -0,0 +1,43 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveChecksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) SaveChecksum(ctx context.Context, module, version string, sum storage.Checksum) error {
+	const op errors.Op = "tiered.SaveChecksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.remote.SaveChecksum(ctx, module, version, sum); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if s.isCached(ctx, module, version) {
+		if err := s.local.SaveChecksum(ctx, module, version, sum); err != nil {
+			// never verify the cached copy against a stale checksum
+			s.drop(ctx, module, version)
+		}
+	}
+	return nil
+}
+
+// Checksum implements the (./pkg/storage).Checksummer interface
+func (s *Storage) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	const op errors.Op = "tiered.Checksum"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if s.isCached(ctx, module, version) {
+		if sum, err := s.local.Checksum(ctx, module, version); err == nil {
+			return sum, nil
+		}
+	}
+	sum, err := s.remote.Checksum(ctx, module, version)
+	if err != nil {
+		return sum, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return sum, nil
+}
@@ -0,0 +1,22 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface.
+// The version is removed from the cache before the remote backend,
+// so that it is never served from the cache after a failed Delete.
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "tiered.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	s.drop(ctx, module, version)
+	if err := s.remote.Delete(ctx, module, version); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package tiered
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "tiered.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	cached, err := s.cached(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if cached {
+		// fall through if the version was evicted meanwhile
+		if info, err := s.local.Info(ctx, module, version); err == nil {
+			return info, nil
+		}
+	}
+	info, err := s.remote.Info(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "tiered.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	cached, err := s.cached(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if cached {
+		// fall through if the version was evicted meanwhile
+		if mod, err := s.local.GoMod(ctx, module, version); err == nil {
+			return mod, nil
+		}
+	}
+	mod, err := s.remote.GoMod(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "tiered.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	cached, err := s.cached(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if cached {
+		// fall through if the version was evicted meanwhile
+		if zip, err := s.local.Zip(ctx, module, version); err == nil {
+			return zip, nil
+		}
+	}
+	zip, err := s.remote.Zip(ctx, module, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return zip, nil
+}
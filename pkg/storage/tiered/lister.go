@@ -0,0 +1,26 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// List implements the (./pkg/storage).Lister interface.
+// The versions are listed by the remote backend. While it is
+// unavailable, the versions in the cache are listed instead.
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "tiered.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	versions, err := s.remote.List(ctx, module)
+	if err == nil {
+		return versions, nil
+	}
+	versions, lerr := s.local.List(ctx, module)
+	if lerr != nil || len(versions) == 0 {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	return versions, nil
+}
@@ -0,0 +1,49 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveMetadata implements the (./pkg/storage).Statter interface
+func (s *Storage) SaveMetadata(ctx context.Context, module, version string, meta storage.Metadata) error {
+	const op errors.Op = "tiered.SaveMetadata"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.remote.SaveMetadata(ctx, module, version, meta); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if s.isCached(ctx, module, version) {
+		if err := s.local.SaveMetadata(ctx, module, version, meta); err != nil {
+			s.drop(ctx, module, version)
+		}
+	}
+	return nil
+}
+
+// Stat implements the (./pkg/storage).Statter interface.
+// The metadata of a cached version is cached along with it
+// the first time it is read.
+func (s *Storage) Stat(ctx context.Context, module, version string) (storage.Metadata, error) {
+	const op errors.Op = "tiered.Stat"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	cached := s.isCached(ctx, module, version)
+	if cached {
+		if meta, err := s.local.Stat(ctx, module, version); err == nil {
+			return meta, nil
+		}
+	}
+	meta, err := s.remote.Stat(ctx, module, version)
+	if err != nil {
+		return meta, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if cached {
+		// the cache is best effort
+		s.local.SaveMetadata(ctx, module, version, meta)
+	}
+	return meta, nil
+}
@@ -0,0 +1,43 @@
+package tiered
+
+import (
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/spf13/afero"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// The version is saved to the remote backend and then cached.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "tiered.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	// spool the zip to disk, it is read once for every tier
+	f, err := afero.TempFile(s.filesystem, "", "athens-tiered")
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	defer func() {
+		f.Close()
+		s.filesystem.Remove(f.Name())
+	}()
+	if _, err := io.Copy(f, zip); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	if err := s.remote.Save(ctx, module, version, mod, f, info); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err == nil {
+		// the cache is best effort, it is filled on the next read otherwise
+		s.store(ctx, module, version, mod, f, info)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package tiered
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SignedURL implements the (./pkg/storage).Signer interface if the
+// remote storage implements it. The URLs always point to the remote
+// storage, the local cache can not be downloaded from directly.
+func (s *Storage) SignedURL(ctx context.Context, module, version, ext string, expiry time.Duration) (string, error) {
+	const op errors.Op = "tiered.SignedURL"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	u, err := storage.SignedURL(ctx, s.remote, module, version, ext, expiry)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	return u, nil
+}
@@ -0,0 +1,52 @@
+package tiered
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface.
+// Responses are written through to the remote storage if it implements
+// it, and kept in the cache either way. They do not count towards the
+// size of the cache.
+func (s *Storage) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	const op errors.Op = "tiered.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	remoteErr := storage.SaveSumDB(ctx, s.remote, path, content)
+	if remoteErr != nil && errors.Kind(remoteErr) != errors.KindNotImplemented {
+		return errors.E(op, remoteErr)
+	}
+	// once in the remote storage, a response that the
+	// cache failed to keep is read from there again
+	if err := s.local.SaveSumDB(ctx, path, content); err != nil && remoteErr != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface.
+// Responses are served from the cache and fill it from the
+// remote storage on a miss.
+func (s *Storage) SumDB(ctx context.Context, path string) ([]byte, error) {
+	const op errors.Op = "tiered.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if content, err := s.local.SumDB(ctx, path); err == nil {
+		return content, nil
+	}
+	content, err := storage.SumDB(ctx, s.remote, path)
+	if errors.Kind(err) == errors.KindNotImplemented {
+		return nil, errors.E(op, errors.M(path), errors.KindNotFound)
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// responses never change, a failed fill only
+	// means that the next read misses as well
+	s.local.SaveSumDB(ctx, path, content)
+	return content, nil
+}
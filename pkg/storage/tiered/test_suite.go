@@ -0,0 +1,73 @@
+package tiered
+
+import (
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/spf13/afero"
+)
+
+const testCacheSize = 64 * 1024 * 1024
+
+// TestSuite implements storage.TestSuite interface
+type TestSuite struct {
+	storage  *Storage
+	fs       afero.Fs
+	rootDirs []string
+}
+
+// NewTestSuite creates a common test suite.
+// Both the cache and the remote backend live in memory.
+func NewTestSuite() (storage.TestSuite, error) {
+	memFs := afero.NewMemMapFs()
+	remoteDir, err := afero.TempDir(memFs, "", "remote")
+	if err != nil {
+		return nil, err
+	}
+	remote, err := fs.NewStorage(remoteDir, memFs)
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := afero.TempDir(memFs, "", "cache")
+	if err != nil {
+		return nil, err
+	}
+	s, err := New(cacheDir, memFs, testCacheSize, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestSuite{
+		storage:  s,
+		fs:       memFs,
+		rootDirs: []string{remoteDir, cacheDir},
+	}, nil
+}
+
+// Storage retrieves initialized storage backend
+func (ts *TestSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+// StorageHumanReadableName retrieves readable identifier of the storage
+func (ts *TestSuite) StorageHumanReadableName() string {
+	return "Tiered"
+}
+
+// Cleanup tears down test
+func (ts *TestSuite) Cleanup() error {
+	for _, dir := range ts.rootDirs {
+		if err := ts.fs.RemoveAll(dir); err != nil {
+			return err
+		}
+		if err := ts.fs.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	// start over with an empty cache index
+	s, err := New(ts.rootDirs[1], ts.fs, testCacheSize, ts.storage.remote)
+	if err != nil {
+		return err
+	}
+	*ts.storage = *s
+	return nil
+}
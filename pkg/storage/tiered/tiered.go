@@ -0,0 +1,52 @@
+// Package tiered provides a storage backend that keeps
+// a bounded cache of module versions on the local disk
+// in front of a remote storage backend.
+//
+// The remote backend is the source of truth. Saves, checksums and
+// deletes are written through to it first and to the cache after.
+// Reads are served from the cache and fill it from the remote backend
+// on a miss. Cached versions keep being served while the remote
+// backend is unavailable.
+package tiered
+
+import (
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/spf13/afero"
+)
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	local      *quota.Storage
+	remote     storage.Backend
+	filesystem afero.Fs
+}
+
+// New returns a Storage that caches the versions of remote in rootDir,
+// using up to maxBytes of disk space. The least recently used versions
+// are evicted first. Versions already cached in rootDir, e.g. by an
+// earlier process, are picked up.
+func New(rootDir string, filesystem afero.Fs, maxBytes int64, remote storage.Backend) (*Storage, error) {
+	const op errors.Op = "tiered.New"
+	fsStore, err := fs.NewStorage(rootDir, filesystem)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	cached, err := fs.Versions(rootDir, filesystem)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &Storage{
+		local:      quota.New(fsStore, maxBytes, nil, cached),
+		remote:     remote,
+		filesystem: filesystem,
+	}, nil
+}
+
+// Stats implements the (./pkg/storage/quota).Statser interface.
+// It returns the usage of the cache.
+func (s *Storage) Stats() (quota.Stats, error) {
+	return s.local.Stats()
+}
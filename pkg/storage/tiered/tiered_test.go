@@ -0,0 +1,237 @@
+package tiered
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/storage"
+	"github.com/gomods/athens/pkg/storage/fs"
+	"github.com/gomods/athens/pkg/storage/quota"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	module  = "github.com/athens-artifacts/tiered"
+	zipSize = 1024
+)
+
+// flakyBackend is a remote backend that can be taken down
+type flakyBackend struct {
+	storage.Backend
+	down bool
+}
+
+func (f *flakyBackend) err() error {
+	return errors.E("flakyBackend", "remote backend is down", errors.KindUnexpected)
+}
+
+func (f *flakyBackend) Exists(ctx context.Context, module, version string) (bool, error) {
+	if f.down {
+		return false, f.err()
+	}
+	return f.Backend.Exists(ctx, module, version)
+}
+
+func (f *flakyBackend) List(ctx context.Context, module string) ([]string, error) {
+	if f.down {
+		return nil, f.err()
+	}
+	return f.Backend.List(ctx, module)
+}
+
+func (f *flakyBackend) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	if f.down {
+		return nil, f.err()
+	}
+	return f.Backend.Zip(ctx, module, version)
+}
+
+func (f *flakyBackend) Checksum(ctx context.Context, module, version string) (storage.Checksum, error) {
+	if f.down {
+		return storage.Checksum{}, f.err()
+	}
+	return f.Backend.Checksum(ctx, module, version)
+}
+
+func (f *flakyBackend) SaveSumDB(ctx context.Context, path string, content []byte) error {
+	if f.down {
+		return f.err()
+	}
+	return storage.SaveSumDB(ctx, f.Backend, path, content)
+}
+
+func (f *flakyBackend) SumDB(ctx context.Context, path string) ([]byte, error) {
+	if f.down {
+		return nil, f.err()
+	}
+	return storage.SumDB(ctx, f.Backend, path)
+}
+
+func newTestStorage(t *testing.T, maxBytes int64) (*Storage, *flakyBackend, afero.Fs) {
+	t.Helper()
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/remote", 0755))
+	require.NoError(t, memFs.MkdirAll("/cache", 0755))
+	fsStore, err := fs.NewStorage("/remote", memFs)
+	require.NoError(t, err)
+	remote := &flakyBackend{Backend: fsStore}
+	s, err := New("/cache", memFs, maxBytes, remote)
+	require.NoError(t, err)
+	return s, remote, memFs
+}
+
+func saveRemote(t *testing.T, b storage.Backend, version string) {
+	t.Helper()
+	zip := make([]byte, zipSize)
+	err := b.Save(context.Background(), module, version, []byte("module "+module), bytes.NewReader(zip), []byte(version))
+	require.NoError(t, err)
+}
+
+func readZip(t *testing.T, s *Storage, version string) {
+	t.Helper()
+	zip, err := s.Zip(context.Background(), module, version)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zip)
+	require.NoError(t, zip.Close())
+	require.NoError(t, err)
+	require.Len(t, b, zipSize)
+}
+
+func TestReadThrough(t *testing.T) {
+	s, remote, _ := newTestStorage(t, 1024*1024)
+	saveRemote(t, remote, "v1.0.0")
+	require.False(t, s.isCached(context.Background(), module, "v1.0.0"))
+
+	readZip(t, s, "v1.0.0")
+	require.True(t, s.isCached(context.Background(), module, "v1.0.0"))
+	exists, err := s.local.Exists(context.Background(), module, "v1.0.0")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestWriteThrough(t *testing.T) {
+	s, remote, _ := newTestStorage(t, 1024*1024)
+	ctx := context.Background()
+	saveRemote(t, s, "v1.0.0")
+
+	exists, err := remote.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.True(t, s.isCached(context.Background(), module, "v1.0.0"))
+
+	require.NoError(t, s.Delete(ctx, module, "v1.0.0"))
+	require.False(t, s.isCached(context.Background(), module, "v1.0.0"))
+	exists, err = remote.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestRemoteDown(t *testing.T) {
+	s, remote, _ := newTestStorage(t, 1024*1024)
+	ctx := context.Background()
+	saveRemote(t, s, "v1.0.0")
+	saveRemote(t, remote, "v1.1.0")
+	remote.down = true
+
+	readZip(t, s, "v1.0.0")
+	exists, err := s.Exists(ctx, module, "v1.0.0")
+	require.NoError(t, err)
+	require.True(t, exists)
+	versions, err := s.List(ctx, module)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0"}, versions)
+
+	// versions that are not cached can not be served
+	_, err = s.Zip(ctx, module, "v1.1.0")
+	require.Error(t, err)
+	_, err = s.List(ctx, "github.com/athens-artifacts/uncached")
+	require.Error(t, err)
+}
+
+func stats(t *testing.T, s *Storage) quota.Stats {
+	t.Helper()
+	st, err := s.Stats()
+	require.NoError(t, err)
+	return st
+}
+
+func TestEviction(t *testing.T) {
+	// room for two versions
+	s, remote, _ := newTestStorage(t, 2*zipSize+512)
+	for i := 0; i < 3; i++ {
+		saveRemote(t, remote, fmt.Sprintf("v1.%d.0", i))
+	}
+
+	readZip(t, s, "v1.0.0")
+	readZip(t, s, "v1.1.0")
+	readZip(t, s, "v1.0.0")
+	readZip(t, s, "v1.2.0")
+	require.True(t, s.isCached(context.Background(), module, "v1.0.0"))
+	require.False(t, s.isCached(context.Background(), module, "v1.1.0"))
+	require.True(t, s.isCached(context.Background(), module, "v1.2.0"))
+	require.True(t, stats(t, s).Bytes <= stats(t, s).MaxBytes)
+	require.Equal(t, int64(1), stats(t, s).Evictions)
+
+	// evicted versions are still served by the remote backend
+	readZip(t, s, "v1.1.0")
+}
+
+func TestTooLargeToCache(t *testing.T) {
+	s, remote, _ := newTestStorage(t, zipSize/2)
+	saveRemote(t, remote, "v1.0.0")
+
+	readZip(t, s, "v1.0.0")
+	require.False(t, s.isCached(context.Background(), module, "v1.0.0"))
+	require.Equal(t, int64(0), stats(t, s).Bytes)
+}
+
+func TestLoad(t *testing.T) {
+	s, remote, memFs := newTestStorage(t, 1024*1024)
+	saveRemote(t, s, "v1.0.0")
+	saveRemote(t, s, "v1.1.0")
+
+	reloaded, err := New("/cache", memFs, 1024*1024, remote)
+	require.NoError(t, err)
+	require.True(t, reloaded.isCached(context.Background(), module, "v1.0.0"))
+	require.True(t, reloaded.isCached(context.Background(), module, "v1.1.0"))
+	require.Equal(t, stats(t, s).Bytes, stats(t, reloaded).Bytes)
+
+	shrunk, err := New("/cache", memFs, zipSize+512, remote)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats(t, shrunk).Versions)
+}
+
+func TestSumDB(t *testing.T) {
+	ctx := context.Background()
+	s, remote, _ := newTestStorage(t, 10*zipSize)
+	const lookup = "sum.golang.org/lookup/github.com/athens-artifacts/tiered@v1.0.0"
+	require.NoError(t, storage.SaveSumDB(ctx, remote.Backend, lookup, []byte("record")))
+
+	// a miss fills the cache, which serves the response while remote is down
+	content, err := s.SumDB(ctx, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "record", string(content))
+	remote.down = true
+	content, err = s.SumDB(ctx, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "record", string(content))
+	require.Error(t, s.SaveSumDB(ctx, lookup, []byte("record")))
+
+	// the cache keeps the responses of remote storages that can not
+	memFs := afero.NewMemMapFs()
+	require.NoError(t, memFs.MkdirAll("/cache", 0755))
+	s, err = New("/cache", memFs, 10*zipSize, struct{ storage.Backend }{remote.Backend})
+	require.NoError(t, err)
+	_, err = s.SumDB(ctx, lookup)
+	require.Equal(t, errors.KindNotFound, errors.Kind(err), "SumDB: %v", err)
+	require.NoError(t, s.SaveSumDB(ctx, lookup, []byte("record")))
+	content, err = s.SumDB(ctx, lookup)
+	require.NoError(t, err)
+	require.Equal(t, "record", string(content))
+}
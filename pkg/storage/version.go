@@ -7,4 +7,10 @@ type Version struct {
 	Mod  []byte
 	Zip  io.ReadCloser
 	Info []byte
+	// Sum and GoModSum are the go.sum hashes of the zip and the .mod file.
+	// They are empty if the fetcher does not provide them.
+	Sum      string
+	GoModSum string
+	// Origin names the fetcher or upstream the version came from
+	Origin string
 }
@@ -0,0 +1,57 @@
+package webdav
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/paths"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// Catalog implements the (./pkg/storage).Cataloger interface.
+// It walks all collections with one PROPFIND each for every page.
+func (s *Storage) Catalog(ctx context.Context, token string, pageSize int) ([]paths.AllPathParams, string, error) {
+	const op errors.Op = "webdav.Catalog"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	all := []paths.AllPathParams{}
+	if err := s.walk(ctx, "", &all); err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	page, next, err := storage.CatalogPage(all, token, pageSize)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+	return page, next, nil
+}
+
+// walk adds the versions in col and all collections below it to all
+func (s *Storage) walk(ctx context.Context, col string, all *[]paths.AllPathParams) error {
+	const op errors.Op = "webdav.walk"
+	members, err := s.members(ctx, col)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for _, m := range members {
+		switch {
+		case m == "@v/":
+			module := strings.TrimSuffix(col, "/")
+			versions, err := s.List(ctx, module)
+			if err != nil {
+				return errors.E(op, err)
+			}
+			for _, v := range versions {
+				*all = append(*all, paths.AllPathParams{Module: module, Version: v})
+			}
+		case col == "" && m == storage.SumDBPrefix:
+			// the responses of checksum databases
+		case strings.HasSuffix(m, "/"):
+			if err := s.walk(ctx, col+m, all); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// davServerMock is an in-process stand-in for a WebDAV server.
+// It keeps files and collections in memory, answers PROPFIND with
+// Depth 1 only and rejects requests without the expected header.
+type davServerMock struct {
+	*httptest.Server
+	authHeader string
+	authValue  string
+
+	lock        sync.Mutex
+	files       map[string][]byte
+	collections map[string]bool
+}
+
+func newDAVServerMock(authHeader, authValue string) *davServerMock {
+	m := &davServerMock{
+		authHeader: authHeader,
+		authValue:  authValue,
+	}
+	m.clear()
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	return m
+}
+
+func (m *davServerMock) clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.files = make(map[string][]byte)
+	// the root collection always exists
+	m.collections = map[string]bool{"/": true}
+}
+
+func (m *davServerMock) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(m.authHeader) != m.authValue {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	name := path.Clean(r.URL.Path)
+	parent := path.Dir(name)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		content, ok := m.files[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(content)
+		}
+	case http.MethodPut:
+		if !m.collections[parent] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		// read everything before storing anything,
+		// like a server that only keeps complete uploads
+		content, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.files[name] = content
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if _, ok := m.files[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(m.files, name)
+		w.WriteHeader(http.StatusNoContent)
+	case "MKCOL":
+		if m.collections[name] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !m.collections[parent] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		m.collections[name] = true
+		w.WriteHeader(http.StatusCreated)
+	case "PROPFIND":
+		if r.Header.Get("Depth") != "1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if !m.collections[name] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		m.propfind(w, name)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type davResponse struct {
+	Href string `xml:"D:href"`
+}
+
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	Namespace string        `xml:"xmlns:D,attr"`
+	Responses []davResponse `xml:"D:response"`
+}
+
+// propfind lists the collection itself and its direct members
+func (m *davServerMock) propfind(w http.ResponseWriter, col string) {
+	hrefs := []string{col + "/"}
+	for c := range m.collections {
+		if c != "/" && c != col && path.Dir(c) == col {
+			hrefs = append(hrefs, c+"/")
+		}
+	}
+	for f := range m.files {
+		if path.Dir(f) == col {
+			hrefs = append(hrefs, f)
+		}
+	}
+	sort.Strings(hrefs)
+
+	ms := davMultistatus{Namespace: "DAV:"}
+	for _, h := range hrefs {
+		u := url.URL{Path: strings.Replace(h, "//", "/", -1)}
+		ms.Responses = append(ms.Responses, davResponse{Href: u.EscapedPath()})
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(ms)
+}
@@ -0,0 +1,30 @@
+package webdav
+
+import (
+	"context"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Delete implements the (./pkg/storage).Deleter interface
+func (s *Storage) Delete(ctx context.Context, module, version string) error {
+	const op errors.Op = "webdav.Delete"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if !exists {
+		return errors.E(op, errors.M(module), errors.V(version), errors.KindNotFound)
+	}
+	// remove the commit marker first so that
+	// the version disappears as a whole
+	for _, ext := range []string{"info", "mod", "zip", "sum", "meta"} {
+		if err := s.remove(ctx, versionedName(module, version, ext)); err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package webdav
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Info implements the (./pkg/storage).Getter interface
+func (s *Storage) Info(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "webdav.Info"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	info, err := s.read(ctx, versionedName(module, version, "info"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return info, nil
+}
+
+// GoMod implements the (./pkg/storage).Getter interface
+func (s *Storage) GoMod(ctx context.Context, module, version string) ([]byte, error) {
+	const op errors.Op = "webdav.GoMod"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.requireExists(ctx, module, version); err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	mod, err := s.read(ctx, versionedName(module, version, "mod"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return mod, nil
+}
+
+// Zip implements the (./pkg/storage).Getter interface
+func (s *Storage) Zip(ctx context.Context, module, version string) (io.ReadCloser, error) {
+	const op errors.Op = "webdav.Zip"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	if err := s.requireExists(ctx, module, version); err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	zip, err := s.get(ctx, versionedName(module, version, "zip"))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	return zip, nil
+}
+
+// requireExists returns an errors.KindNotFound error if there is
+// no complete version, as a failed Save may leave other files behind
+func (s *Storage) requireExists(ctx context.Context, module, version string) error {
+	const op errors.Op = "webdav.requireExists"
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !exists {
+		return errors.E(op, errors.KindNotFound)
+	}
+	return nil
+}
+
+func (s *Storage) read(ctx context.Context, p string) ([]byte, error) {
+	const op errors.Op = "webdav.read"
+	rc, err := s.get(ctx, p)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,93 @@
+package webdav
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/semver"
+)
+
+// propfindBody asks for as little as possible,
+// only the names of the members are needed
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:propfind xmlns:D="DAV:"><D:prop><D:resourcetype/></D:prop></D:propfind>`
+
+// multistatus is the body of a PROPFIND response
+type multistatus struct {
+	Responses []struct {
+		Href string `xml:"href"`
+	} `xml:"DAV: response"`
+}
+
+// List implements the (./pkg/storage).Lister interface.
+// It lists the <module>/@v/ collection, which never holds the
+// files of nested modules as module paths never contain "@".
+func (s *Storage) List(ctx context.Context, module string) ([]string, error) {
+	const op errors.Op = "webdav.List"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	members, err := s.members(ctx, versionsLocation(module))
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(module))
+	}
+	versions := []string{}
+	for _, m := range members {
+		if strings.HasSuffix(m, ".info") {
+			versions = append(versions, strings.TrimSuffix(m, ".info"))
+		}
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
+
+// members returns the names of the members of the collection col,
+// the names of collections end in a slash. A missing collection
+// has no members.
+func (s *Storage) members(ctx context.Context, col string) ([]string, error) {
+	const op errors.Op = "webdav.members"
+	header := http.Header{}
+	header.Set("Depth", "1")
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := s.do(ctx, "PROPFIND", col, strings.NewReader(propfindBody), header)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, errors.E(op, statusErr(resp))
+	}
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	colPath := path.Clean("/" + path.Join(s.baseURL.Path, col))
+	members := []string{}
+	for _, r := range ms.Responses {
+		// hrefs are escaped and may be absolute URLs
+		u, err := url.Parse(strings.TrimSpace(r.Href))
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		p := path.Clean("/" + u.Path)
+		// Depth 1 includes the collection itself
+		if p == colPath || path.Dir(p) != colPath {
+			continue
+		}
+		name := path.Base(p)
+		if strings.HasSuffix(u.Path, "/") {
+			name += "/"
+		}
+		members = append(members, name)
+	}
+	return members, nil
+}
@@ -0,0 +1,45 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+)
+
+// Save implements the (./pkg/storage).Saver interface.
+// It uploads the .mod and .zip files of a version before its .info file.
+// The .info file is the commit marker of a version, so a failed Save
+// never leaves a version behind that readers can see.
+func (s *Storage) Save(ctx context.Context, module, version string, mod []byte, zip io.Reader, info []byte) error {
+	const op errors.Op = "webdav.Save"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	exists, err := s.Exists(ctx, module, version)
+	if err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	if exists {
+		return errors.E(op, "already exists", errors.M(module), errors.V(version), errors.KindAlreadyExists)
+	}
+
+	if err := s.mkcol(ctx, versionsLocation(module)); err != nil {
+		return errors.E(op, err, errors.M(module), errors.V(version))
+	}
+	files := []struct {
+		ext     string
+		content io.Reader
+	}{
+		{ext: "mod", content: bytes.NewReader(mod)},
+		{ext: "zip", content: zip},
+		{ext: "info", content: bytes.NewReader(info)},
+	}
+	for _, f := range files {
+		if err := s.put(ctx, versionedName(module, version, f.ext), f.content); err != nil {
+			return errors.E(op, err, errors.M(module), errors.V(version))
+		}
+	}
+	return nil
+}
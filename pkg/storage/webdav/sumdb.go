@@ -0,0 +1,38 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"path"
+
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/gomods/athens/pkg/observ"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+// SaveSumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SaveSumDB(ctx context.Context, p string, content []byte) error {
+	const op errors.Op = "webdav.SaveSumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	name := storage.SumDBPrefix + p
+	if err := s.mkcol(ctx, path.Dir(name)); err != nil {
+		return errors.E(op, err, errors.M(p))
+	}
+	if err := s.put(ctx, name, bytes.NewReader(content)); err != nil {
+		return errors.E(op, err, errors.M(p))
+	}
+	return nil
+}
+
+// SumDB implements the (./pkg/storage).SumDBCacher interface
+func (s *Storage) SumDB(ctx context.Context, p string) ([]byte, error) {
+	const op errors.Op = "webdav.SumDB"
+	ctx, span := observ.StartSpan(ctx, op.String())
+	defer span.End()
+	content, err := s.read(ctx, storage.SumDBPrefix+p)
+	if err != nil {
+		return nil, errors.E(op, err, errors.M(p))
+	}
+	return content, nil
+}
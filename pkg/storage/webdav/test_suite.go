@@ -0,0 +1,53 @@
+package webdav
+
+import (
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/storage"
+)
+
+const (
+	mockAuthHeader = "X-Api-Key"
+	mockAuthValue  = "athens-test-key"
+)
+
+// TestSuite implements storage.TestSuite interface
+type TestSuite struct {
+	storage *Storage
+	server  *davServerMock
+}
+
+// NewTestSuite creates a common test suite backed
+// by an in-process stand-in for a WebDAV server
+func NewTestSuite() (storage.TestSuite, error) {
+	server := newDAVServerMock(mockAuthHeader, mockAuthValue)
+	davStore, err := New(&config.WebDAVConfig{
+		URL:         server.URL + "/",
+		Headers:     map[string]string{mockAuthHeader: mockAuthValue},
+		TimeoutConf: config.TimeoutConf{Timeout: 300},
+	})
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	return &TestSuite{
+		storage: davStore,
+		server:  server,
+	}, nil
+}
+
+// Storage retrieves initialized storage backend
+func (ts *TestSuite) Storage() storage.Backend {
+	return ts.storage
+}
+
+// StorageHumanReadableName retrieves readable identifier of the storage
+func (ts *TestSuite) StorageHumanReadableName() string {
+	return "WebDAV"
+}
+
+// Cleanup tears down test
+func (ts *TestSuite) Cleanup() error {
+	ts.server.clear()
+	return nil
+}
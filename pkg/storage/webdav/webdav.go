@@ -0,0 +1,184 @@
+// Package webdav implements the (./pkg/storage).Backend interface
+// on top of any HTTP server that speaks the WebDAV methods
+// GET, HEAD, PUT, DELETE, MKCOL and PROPFIND.
+//
+// Module versions are stored in the layout of the download protocol:
+//
+//	<URL>/<module>/@v/<version>.info
+//	<URL>/<module>/@v/<version>.mod
+//	<URL>/<module>/@v/<version>.zip
+//	<URL>/<module>/@v/<version>.sum
+//
+// so that the collection can also be served as a GOPROXY as is.
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+)
+
+// Storage implements the (./pkg/storage).Backend interface
+type Storage struct {
+	baseURL  *url.URL
+	client   *http.Client
+	username string
+	password string
+	headers  map[string]string
+}
+
+// New returns a new Storage that stores module versions in the WebDAV
+// collection at conf.URL, which must exist. Every request carries
+// conf.Headers and, if conf.Username is set, basic auth credentials.
+func New(conf *config.WebDAVConfig) (*Storage, error) {
+	const op errors.Op = "webdav.New"
+	u, err := url.Parse(conf.URL)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.E(op, fmt.Errorf("unsupported URL scheme %q", u.Scheme))
+	}
+	return &Storage{
+		baseURL:  u,
+		client:   &http.Client{Timeout: conf.TimeoutDuration()},
+		username: conf.Username,
+		password: conf.Password,
+		headers:  conf.Headers,
+	}, nil
+}
+
+func versionedName(module, version, ext string) string {
+	return config.PackageVersionedName(module, version, ext)
+}
+
+func versionsLocation(module string) string {
+	return module + "/@v/"
+}
+
+// url returns the URL of a path relative to the base URL.
+// A trailing slash, which marks a collection, is kept.
+func (s *Storage) url(p string) string {
+	u := *s.baseURL
+	u.Path = path.Join(u.Path, p)
+	if strings.HasSuffix(p, "/") {
+		u.Path += "/"
+	}
+	return u.String()
+}
+
+func (s *Storage) do(ctx context.Context, method, p string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.url(p), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	return s.client.Do(req)
+}
+
+// statusErr describes an unexpected response status
+func statusErr(resp *http.Response) error {
+	return fmt.Errorf("%s %s: unexpected status %s", resp.Request.Method, resp.Request.URL, resp.Status)
+}
+
+// mkcol creates the collection of p and all collections above it,
+// relative to the base URL. Collections that exist already are fine.
+func (s *Storage) mkcol(ctx context.Context, p string) error {
+	const op errors.Op = "webdav.mkcol"
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := range segments {
+		col := strings.Join(segments[:i+1], "/") + "/"
+		resp, err := s.do(ctx, "MKCOL", col, nil, nil)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		resp.Body.Close()
+		// 405 Method Not Allowed is the answer for an existing collection
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+			return errors.E(op, statusErr(resp))
+		}
+	}
+	return nil
+}
+
+// put uploads content to p, whose collection must exist
+func (s *Storage) put(ctx context.Context, p string, content io.Reader) error {
+	const op errors.Op = "webdav.put"
+	resp, err := s.do(ctx, http.MethodPut, p, content, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return errors.E(op, statusErr(resp))
+	}
+	return nil
+}
+
+// get returns the body of p or an errors.KindNotFound error
+func (s *Storage) get(ctx context.Context, p string) (io.ReadCloser, error) {
+	const op errors.Op = "webdav.get"
+	resp, err := s.do(ctx, http.MethodGet, p, nil, nil)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.E(op, errors.KindNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.E(op, statusErr(resp))
+	}
+	return resp.Body, nil
+}
+
+// exists returns true if there is a file at p
+func (s *Storage) exists(ctx context.Context, p string) (bool, error) {
+	const op errors.Op = "webdav.exists"
+	resp, err := s.do(ctx, http.MethodHead, p, nil, nil)
+	if err != nil {
+		return false, errors.E(op, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.E(op, statusErr(resp))
+	}
+}
+
+// remove deletes p, a missing file is not an error
+func (s *Storage) remove(ctx context.Context, p string) error {
+	const op errors.Op = "webdav.remove"
+	resp, err := s.do(ctx, http.MethodDelete, p, nil, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return errors.E(op, statusErr(resp))
+	}
+}
@@ -0,0 +1,61 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/gomods/athens/pkg/config"
+	"github.com/gomods/athens/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	module  = "github.com/athens-artifacts/webdav"
+	version = "v1.0.0"
+)
+
+func save(s *Storage) error {
+	return s.Save(context.Background(), module, version, []byte("module "+module), bytes.NewReader([]byte("zip")), []byte("{}"))
+}
+
+func TestMissingAuthHeader(t *testing.T) {
+	server := newDAVServerMock(mockAuthHeader, mockAuthValue)
+	defer server.Close()
+	s, err := New(&config.WebDAVConfig{
+		URL:         server.URL,
+		TimeoutConf: config.TimeoutConf{Timeout: 300},
+	})
+	require.NoError(t, err)
+
+	require.Error(t, save(s))
+	_, err = s.Exists(context.Background(), module, version)
+	require.Error(t, err)
+	// an unauthorized request is not mistaken for a missing version
+	_, err = s.Info(context.Background(), module, version)
+	require.NotEqual(t, errors.KindNotFound, errors.Kind(err))
+}
+
+func TestBasicAuth(t *testing.T) {
+	creds := base64.StdEncoding.EncodeToString([]byte("athens:secret"))
+	server := newDAVServerMock("Authorization", "Basic "+creds)
+	defer server.Close()
+	s, err := New(&config.WebDAVConfig{
+		URL:         server.URL,
+		Username:    "athens",
+		Password:    "secret",
+		TimeoutConf: config.TimeoutConf{Timeout: 300},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, save(s))
+	versions, err := s.List(context.Background(), module)
+	require.NoError(t, err)
+	require.Equal(t, []string{version}, versions)
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	_, err := New(&config.WebDAVConfig{URL: "ftp://dav.example.com"})
+	require.Error(t, err)
+}